@@ -0,0 +1,107 @@
+// Command migrate applies, rolls back and lists the database migrations
+// embedded in the binary.
+//
+//	migrate up [N]     apply all pending migrations, or only the next N
+//	migrate down [N]   roll back the last migration, or the last N
+//	migrate status     list migrations and when they were applied
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/srmbackisdeveloper/test-music-info/config"
+	"github.com/srmbackisdeveloper/test-music-info/internal/migrations"
+	"github.com/srmbackisdeveloper/test-music-info/internal/repositories"
+	"github.com/srmbackisdeveloper/test-music-info/pkg/logger"
+)
+
+const usage = "usage: migrate up [N] | down [N] | status"
+
+func main() {
+	if len(os.Args) < 2 || len(os.Args) > 3 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	command := os.Args[1]
+	count := 0
+	if len(os.Args) == 3 {
+		n, err := strconv.Atoi(os.Args[2])
+		if err != nil || n < 1 {
+			fmt.Fprintln(os.Stderr, usage)
+			os.Exit(2)
+		}
+		count = n
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+	appLog := logger.New(cfg.LogLevel)
+
+	all, err := migrations.All()
+	if err != nil {
+		appLog.Fatalf("failed to load migrations: %v", err)
+	}
+
+	db, err := repositories.NewPostgresDB(cfg.PostgresDSN)
+	if err != nil {
+		appLog.Fatalf("failed to connect to PostgreSQL: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		appLog.Fatalf("failed to connect to PostgreSQL: %v", err)
+	}
+	defer sqlDB.Close()
+
+	migrator, err := repositories.NewMigrator(db, all)
+	if err != nil {
+		appLog.Fatalf("failed to connect to PostgreSQL: %v", err)
+	}
+	switch command {
+	case "up":
+		applied, err := migrator.Up(count)
+		if err != nil {
+			appLog.Fatalf("failed to apply migrations: %v", err)
+		}
+		if err := repositories.Backfill(db, migrator); err != nil {
+			appLog.Fatalf("%v", err)
+		}
+		appLog.Infof("Applied %d migrations", len(applied))
+	case "down":
+		if count == 0 {
+			count = 1
+		}
+		rolledBack, err := migrator.Down(count)
+		if err != nil {
+			appLog.Fatalf("failed to roll back migrations: %v", err)
+		}
+		appLog.Infof("Rolled back %d migrations", len(rolledBack))
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			appLog.Fatalf("failed to read migration status: %v", err)
+		}
+		printStatus(statuses)
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func printStatus(statuses []migrations.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05 MST")
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+	w.Flush()
+}
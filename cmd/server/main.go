@@ -8,6 +8,13 @@
 // @license.url https://opensource.org/licenses/MIT
 // @host localhost:8080
 // @BasePath /
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Type "Bearer" followed by a space and the JWT from /auth/login.
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
 
 package main
 
@@ -15,16 +22,15 @@ import (
 	"log"
 	"time"
 
-	"github.com/gin-gonic/gin"
 	"github.com/srmbackisdeveloper/test-music-info/config"
 	"github.com/srmbackisdeveloper/test-music-info/internal/handlers"
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
 	"github.com/srmbackisdeveloper/test-music-info/internal/repositories"
+	"github.com/srmbackisdeveloper/test-music-info/internal/server"
 	"github.com/srmbackisdeveloper/test-music-info/internal/services"
 	"github.com/srmbackisdeveloper/test-music-info/pkg/logger"
 
 	_ "github.com/srmbackisdeveloper/test-music-info/docs"
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
 func main() {
@@ -34,7 +40,10 @@ func main() {
     }
 
     appLog := logger.New(cfg.LogLevel)
-    appLog.Infof("Configuration loaded successfully: %+v", cfg)
+    if cfg.JWTSigningKey == "" {
+        appLog.Fatalf("JWT_SIGNING_KEY must be set")
+    }
+    appLog.Infof("Configuration loaded successfully")
 
     // permanent repo (postgres): musicRepo
     appLog.Debug("Connecting to PostgreSQL...")
@@ -49,7 +58,20 @@ func main() {
     }()
     appLog.Infof("Connected to PostgreSQL successfully")
 
+    if cfg.MigrateOnStart {
+        appLog.Debug("Applying database migrations...")
+        if err := repositories.MigrateDB(db); err != nil {
+            appLog.Fatalf("failed to migrate database: %v", err)
+        }
+    }
+
     musicRepo := repositories.NewMusicRepository(db)
+    artistRepo := repositories.NewArtistRepository(db)
+    revisionRepo := repositories.NewRevisionRepository(db)
+    albumRepo := repositories.NewAlbumRepository(db)
+    playlistRepo := repositories.NewPlaylistRepository(db)
+    userRepo := repositories.NewUserRepository(db)
+    apiKeyRepo := repositories.NewAPIKeyRepository(db)
 
     // cache repo (redis): cacheRepo
     appLog.Debug("Connecting to Redis...")
@@ -58,34 +80,66 @@ func main() {
     appLog.Infof("Connected to Redis successfully")
 
     cacheRepo := repositories.NewCacheRepository(cache)
+    rateLimitRepo := repositories.NewRateLimitRepository(cache)
     
     // services
     appLog.Debug("Initializing music service...")
-    musicService := services.NewMusicService(musicRepo, cacheRepo, 4*time.Hour)
+    enrichers := []services.Enricher{
+        newResilientEnricher(cfg, services.NewSongDetailClient("primary", cfg.SongDetailAPIURL, cfg.SongDetailAPITimeout)),
+    }
+    if cfg.SongDetailFallbackAPIURL != "" {
+        enrichers = append(enrichers, newResilientEnricher(cfg, services.NewSongDetailClient("secondary", cfg.SongDetailFallbackAPIURL, cfg.SongDetailFallbackAPITimeout)))
+    }
+    if cfg.SongCatalogPath != "" {
+        catalog, err := services.NewCatalogEnricher("catalog", cfg.SongCatalogPath)
+        if err != nil {
+            appLog.Fatalf("failed to load song catalog: %v", err)
+        }
+        enrichers = append(enrichers, catalog)
+    }
+    musicService := services.NewMusicService(musicRepo, artistRepo, revisionRepo, cacheRepo, services.NewEnrichmentChain(enrichers...), 4*time.Hour)
+    musicService.TrashRetention = cfg.TrashRetention
+    musicService.Transactor = repositories.NewSongTransaction(db)
+    artistService := services.NewArtistService(artistRepo, musicRepo, albumRepo)
+    albumService := services.NewAlbumService(albumRepo, artistRepo, musicService)
+    playlistService := services.NewPlaylistService(playlistRepo, musicRepo)
+    authService := services.NewAuthService(userRepo, cfg.JWTSigningKey, cfg.JWTTokenTTL)
+    apiKeyService := services.NewAPIKeyService(apiKeyRepo)
+    rateLimiter, err := newRateLimiter(cfg, rateLimitRepo)
+    if err != nil {
+        appLog.Fatalf("failed to configure rate limits: %v", err)
+    }
+    if cfg.AdminUsername != "" {
+        if err := authService.EnsureUser(cfg.AdminUsername, cfg.AdminPassword, models.RoleAdmin); err != nil {
+            appLog.Fatalf("failed to create admin user: %v", err)
+        }
+    }
     appLog.Infof("Music service initialized successfully")
 
     // handlers
     appLog.Debug("Initializing handlers...")
     musicHandler := handlers.NewMusicHandler(musicService)
+    musicHandler.RequireIfMatch = cfg.RequireIfMatch
+    musicHandler.DefaultCacheControl = cfg.CacheControlDefault
+    musicHandler.CacheControl, err = handlers.ParseCacheControlRoutes(cfg.CacheControlRoutes)
+    if err != nil {
+        appLog.Fatalf("failed to configure cache policies: %v", err)
+    }
+    artistHandler := handlers.NewArtistHandler(artistService)
+    albumHandler := handlers.NewAlbumHandler(albumService)
+    playlistHandler := handlers.NewPlaylistHandler(playlistService)
+    authHandler := handlers.NewAuthHandler(authService)
+    apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService)
     appLog.Infof("Handlers initialized successfully")
 
     // server
     appLog.Debug("Setting up server routes...")
-    router := gin.Default()
+    router := server.NewRouter(server.Handlers{Music: musicHandler, Artist: artistHandler, Album: albumHandler, Playlist: playlistHandler, Auth: authHandler, APIKey: apiKeyHandler}, authService, apiKeyService, rateLimiter)
+    if err := server.SetTrustedProxies(router, cfg.TrustedProxies); err != nil {
+        appLog.Fatalf("failed to configure trusted proxies: %v", err)
+    }
     port := cfg.Port
 
-    
-    router.GET("/info", musicHandler.GetSong)
-	router.POST("/music", musicHandler.AddSong)
-	router.GET("/music", musicHandler.ListSongs)
-	router.PUT("/music/:id", musicHandler.UpdateSong)
-	router.DELETE("/music/:id", musicHandler.DeleteSong)
-
-    // show lyrics
-    router.GET("/lyrics/:id", musicHandler.GetLyrics)
-    // swagger
-    router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-
     appLog.Infof("Server routes setup complete")
 
 
@@ -94,3 +148,20 @@ func main() {
         appLog.Fatalf("failed to start server: %v", err)
     }
 }
+
+func newResilientEnricher(cfg *config.Config, enricher services.Enricher) services.Enricher {
+    breaker := services.NewCircuitBreaker(cfg.EnrichBreakerThreshold, cfg.EnrichBreakerCooldown)
+    return services.NewResilientEnricher(enricher, cfg.EnrichMaxRetries, cfg.EnrichRetryBackoff, breaker)
+}
+
+func newRateLimiter(cfg *config.Config, store repositories.RateLimitStore) (*services.RateLimiter, error) {
+    defaultRule, err := services.ParseRateLimitRule(cfg.RateLimitDefault)
+    if err != nil {
+        return nil, err
+    }
+    routes, err := services.ParseRateLimitRoutes(cfg.RateLimitRoutes)
+    if err != nil {
+        return nil, err
+    }
+    return services.NewRateLimiter(store, defaultRule, routes), nil
+}
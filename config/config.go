@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -15,6 +16,36 @@ type Config struct {
 	RedisDB       int
 	LogLevel      string
 	Port  string
+
+	SongDetailAPIURL     string
+	SongDetailAPITimeout time.Duration
+
+	SongDetailFallbackAPIURL     string
+	SongDetailFallbackAPITimeout time.Duration
+	SongCatalogPath              string
+
+	EnrichMaxRetries       int
+	EnrichRetryBackoff     time.Duration
+	EnrichBreakerThreshold int
+	EnrichBreakerCooldown  time.Duration
+
+	JWTSigningKey string
+	JWTTokenTTL   time.Duration
+	AdminUsername string
+	AdminPassword string
+
+	RateLimitDefault string
+	RateLimitRoutes  string
+	TrustedProxies   string
+
+	MigrateOnStart bool
+
+	TrashRetention time.Duration
+
+	RequireIfMatch bool
+
+	CacheControlDefault string
+	CacheControlRoutes  string
 }
 
 func LoadConfig() (*Config, error) {
@@ -29,6 +60,27 @@ func LoadConfig() (*Config, error) {
 		RedisDB: getEnvInt("REDIS_DB", 0),
 		LogLevel: getEnv("LOG_LEVEL", "info"),
 		Port: getEnv("PORT", "8080"),
+		SongDetailAPIURL: getEnv("SONG_DETAIL_API_URL", "http://localhost:8081"),
+		SongDetailAPITimeout: getEnvDuration("SONG_DETAIL_API_TIMEOUT", 5*time.Second),
+		SongDetailFallbackAPIURL: getEnv("SONG_DETAIL_FALLBACK_API_URL", ""),
+		SongDetailFallbackAPITimeout: getEnvDuration("SONG_DETAIL_FALLBACK_API_TIMEOUT", 5*time.Second),
+		SongCatalogPath: getEnv("SONG_CATALOG_PATH", ""),
+		EnrichMaxRetries: getEnvInt("ENRICH_MAX_RETRIES", 2),
+		EnrichRetryBackoff: getEnvDuration("ENRICH_RETRY_BACKOFF", 200*time.Millisecond),
+		EnrichBreakerThreshold: getEnvInt("ENRICH_BREAKER_THRESHOLD", 5),
+		EnrichBreakerCooldown: getEnvDuration("ENRICH_BREAKER_COOLDOWN", 30*time.Second),
+		JWTSigningKey: getEnv("JWT_SIGNING_KEY", ""),
+		JWTTokenTTL: getEnvDuration("JWT_TOKEN_TTL", 24*time.Hour),
+		AdminUsername: getEnv("ADMIN_USERNAME", ""),
+		AdminPassword: getEnv("ADMIN_PASSWORD", ""),
+		RateLimitDefault: getEnv("RATE_LIMIT_DEFAULT", "300/1m"),
+		RateLimitRoutes: getEnv("RATE_LIMIT_ROUTES", "GET /info=60/1m,GET /search=30/1m,POST /auth/login=10/1m"),
+		TrustedProxies: getEnv("TRUSTED_PROXIES", ""),
+		MigrateOnStart: getEnvBool("MIGRATE_ON_START", true),
+		TrashRetention: getEnvDuration("TRASH_RETENTION", 30*24*time.Hour),
+		RequireIfMatch: getEnvBool("REQUIRE_IF_MATCH", false),
+		CacheControlDefault: getEnv("CACHE_CONTROL_DEFAULT", "private, no-cache"),
+		CacheControlRoutes: getEnv("CACHE_CONTROL_ROUTES", ""),
 	}, nil
 }
 
@@ -47,4 +99,22 @@ func getEnvInt(key string, defaultValue int) int {
         }
     }
     return defaultValue
-}
\ No newline at end of file
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value, exists := os.LookupEnv(key); exists {
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			return boolVal
+		}
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		if durationVal, err := time.ParseDuration(value); err == nil {
+			return durationVal
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srmbackisdeveloper/test-music-info/internal/middleware"
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/services"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"gorm.io/gorm"
+)
+
+type AlbumHandler struct {
+	AlbumService services.AlbumServicer
+}
+
+func NewAlbumHandler(albumService services.AlbumServicer) *AlbumHandler {
+	return &AlbumHandler{AlbumService: albumService}
+}
+
+// AddAlbum godoc
+// @Summary Add a new album
+// @Description Creates an album with an optional ordered tracklist. Tracks without a release date inherit the album's.
+// @Tags Albums
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param album body types.AddAlbumRequest true "Album to create"
+// @Success 201 {object} models.Album "The created album"
+// @Failure 400 {object} types.ErrorResponse "Invalid request payload, unknown artist or invalid tracks"
+// @Failure 500 {object} types.ErrorResponse "Failed to add the album"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Insufficient permissions"
+// @Router /albums [post]
+func (h *AlbumHandler) AddAlbum(c *gin.Context) {
+	log.Println("AddAlbum: Received request to add an album")
+	var req types.AddAlbumRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("AddAlbum: Invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: 'title' is required, 'releaseDate' must be YYYY-MM-DD and 'type' one of album, single, ep, compilation"})
+		return
+	}
+
+	album := &models.Album{
+		Title:    req.Title,
+		ArtistID: req.ArtistID,
+		Type:     req.Type,
+	}
+	if req.ReleaseDate != "" {
+		album.ReleaseDate, _ = time.Parse("2006-01-02", req.ReleaseDate)
+	}
+
+	log.Printf("AddAlbum: Adding album '%s' with %d tracks", album.Title, len(req.SongIDs))
+	if err := h.AlbumService.AddAlbum(album, req.SongIDs, middleware.CurrentSubject(c)); err != nil {
+		switch {
+		case errors.Is(err, services.ErrArtistNotFound):
+			log.Println("AddAlbum: Artist not found")
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Artist not found"})
+		case errors.Is(err, services.ErrInvalidTrack):
+			log.Printf("AddAlbum: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			log.Println("AddAlbum: Failed to add album")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add album"})
+		}
+		return
+	}
+
+	created, err := h.AlbumService.GetAlbumByID(album.ID)
+	if err != nil {
+		log.Println("AddAlbum: Failed to fetch created album")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch album"})
+		return
+	}
+
+	log.Println("AddAlbum: Album added successfully")
+	c.JSON(http.StatusCreated, gin.H{"message": "Album added successfully", "album": created})
+}
+
+// GetAlbum godoc
+// @Summary Retrieve an album
+// @Description Fetches an album with its ordered tracklist
+// @Tags Albums
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "The ID of the album"
+// @Success 200 {object} models.Album "The requested album"
+// @Failure 400 {object} types.ErrorResponse "Invalid album ID"
+// @Failure 404 {object} types.ErrorResponse "Album not found"
+// @Failure 500 {object} types.ErrorResponse "Failed to fetch the album"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Router /albums/{id} [get]
+func (h *AlbumHandler) GetAlbum(c *gin.Context) {
+	log.Println("GetAlbum: Received request to fetch an album")
+	albumID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || albumID <= 0 {
+		log.Println("GetAlbum: Invalid album ID")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid album ID"})
+		return
+	}
+
+	album, err := h.AlbumService.GetAlbumByID(uint(albumID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Println("GetAlbum: Album not found")
+			c.JSON(http.StatusNotFound, gin.H{"error": "Album not found"})
+			return
+		}
+		log.Println("GetAlbum: Failed to fetch album")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch album"})
+		return
+	}
+
+	log.Println("GetAlbum: Album fetched successfully")
+	c.JSON(http.StatusOK, album)
+}
+
+// ListAlbums godoc
+// @Summary List all albums
+// @Description Retrieves a paginated list of albums, newest release first
+// @Tags Albums
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Number of albums per page (default: 10)"
+// @Success 200 {object} types.PaginatedAlbumsResponse "Paginated list of albums"
+// @Failure 500 {object} types.ErrorResponse "Failed to fetch the list of albums"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Router /albums [get]
+func (h *AlbumHandler) ListAlbums(c *gin.Context) {
+	log.Println("ListAlbums: Received request to list albums")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	albums, totalAlbums, err := h.AlbumService.ListAlbums(limit, (page-1)*limit)
+	if err != nil {
+		log.Println("ListAlbums: Failed to list albums")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list albums"})
+		return
+	}
+
+	log.Println("ListAlbums: Albums listed successfully")
+	c.JSON(http.StatusOK, types.PaginatedAlbumsResponse{
+		Page:        page,
+		Limit:       limit,
+		TotalPages:  (totalAlbums + limit - 1) / limit,
+		TotalAlbums: totalAlbums,
+		Data:        albums,
+	})
+}
+
+// SetTracks godoc
+// @Summary Replace or reorder an album's tracklist
+// @Description Sets the album's tracks to the given song IDs in play order. Tracks without a release date inherit the album's.
+// @Tags Albums
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "The ID of the album"
+// @Param tracks body types.TracklistRequest true "Song IDs in play order"
+// @Success 200 {object} models.Album "The album with its new tracklist"
+// @Failure 400 {object} types.ErrorResponse "Invalid album ID, payload or tracks"
+// @Failure 404 {object} types.ErrorResponse "Album not found"
+// @Failure 500 {object} types.ErrorResponse "Failed to update the tracklist"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Insufficient permissions"
+// @Router /albums/{id}/tracks [put]
+func (h *AlbumHandler) SetTracks(c *gin.Context) {
+	log.Println("SetTracks: Received request to update a tracklist")
+	albumID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || albumID <= 0 {
+		log.Println("SetTracks: Invalid album ID")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid album ID"})
+		return
+	}
+
+	var req types.TracklistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("SetTracks: Invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: 'songIds' is required"})
+		return
+	}
+
+	log.Printf("SetTracks: Setting %d tracks on album %d", len(req.SongIDs), albumID)
+	album, err := h.AlbumService.SetTracks(uint(albumID), req.SongIDs, middleware.CurrentSubject(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			log.Println("SetTracks: Album not found")
+			c.JSON(http.StatusNotFound, gin.H{"error": "Album not found"})
+		case errors.Is(err, services.ErrInvalidTrack):
+			log.Printf("SetTracks: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			log.Println("SetTracks: Failed to update tracklist")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update tracklist"})
+		}
+		return
+	}
+
+	log.Println("SetTracks: Tracklist updated successfully")
+	c.JSON(http.StatusOK, gin.H{"message": "Tracklist updated successfully", "album": album})
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srmbackisdeveloper/test-music-info/internal/middleware"
+	"github.com/srmbackisdeveloper/test-music-info/internal/services"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"gorm.io/gorm"
+)
+
+type APIKeyHandler struct {
+	APIKeyService services.APIKeyServicer
+}
+
+func NewAPIKeyHandler(apiKeyService services.APIKeyServicer) *APIKeyHandler {
+	return &APIKeyHandler{APIKeyService: apiKeyService}
+}
+
+// CreateAPIKey godoc
+// @Summary Create an API key
+// @Description Creates an API key for a service client (admin only). Scopes are read (GET routes), write (create and update) and admin (delete and user management). The plaintext key is only returned by this call; send it as the "X-API-Key" header.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param apiKey body types.AddAPIKeyRequest true "Key name, scopes and optional expiry"
+// @Success 201 {object} types.APIKeyCreatedResponse "The created key and its plaintext value"
+// @Failure 400 {object} types.ErrorResponse "Invalid request payload"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Insufficient permissions"
+// @Failure 500 {object} types.ErrorResponse "Failed to create the API key"
+// @Router /api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	log.Println("CreateAPIKey: Received request to create an API key")
+	var req types.AddAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("CreateAPIKey: Invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: 'name' and 'scopes' (read, write, admin) are required"})
+		return
+	}
+
+	claims, _ := middleware.CurrentClaims(c)
+	key, rawKey, err := h.APIKeyService.CreateAPIKey(req.Name, req.Scopes, req.ExpiresAt, claims.Subject)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidExpiry) {
+			log.Println("CreateAPIKey: Expiry in the past")
+			c.JSON(http.StatusBadRequest, gin.H{"error": "'expiresAt' must be in the future"})
+			return
+		}
+		log.Println("CreateAPIKey: Failed to create API key")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create API key"})
+		return
+	}
+
+	log.Printf("CreateAPIKey: API key '%s' (%s) created", key.Name, key.Prefix)
+	c.JSON(http.StatusCreated, types.APIKeyCreatedResponse{
+		Message: "API key created successfully",
+		Key:     rawKey,
+		APIKey:  *key,
+	})
+}
+
+// ListAPIKeys godoc
+// @Summary List API keys
+// @Description Retrieves a paginated list of API keys, including revoked and expired ones (admin only)
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Number of keys per page (default: 10)"
+// @Success 200 {object} types.PaginatedAPIKeysResponse "Paginated list of API keys"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Insufficient permissions"
+// @Failure 500 {object} types.ErrorResponse "Failed to fetch the list of API keys"
+// @Router /api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	log.Println("ListAPIKeys: Received request to list API keys")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	keys, totalKeys, err := h.APIKeyService.ListAPIKeys(limit, (page-1)*limit)
+	if err != nil {
+		log.Println("ListAPIKeys: Failed to list API keys")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list API keys"})
+		return
+	}
+
+	log.Println("ListAPIKeys: API keys listed successfully")
+	c.JSON(http.StatusOK, types.PaginatedAPIKeysResponse{
+		Page:         page,
+		Limit:        limit,
+		TotalPages:   (totalKeys + limit - 1) / limit,
+		TotalAPIKeys: totalKeys,
+		Data:         keys,
+	})
+}
+
+// RevokeAPIKey godoc
+// @Summary Revoke an API key
+// @Description Revokes an API key so it can no longer authenticate (admin only). The key stays listed with its revocation time.
+// @Tags Auth
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "The ID of the API key"
+// @Success 200 {object} types.MessageResponse "Revocation success message"
+// @Failure 400 {object} types.ErrorResponse "Invalid API key ID"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Insufficient permissions"
+// @Failure 404 {object} types.ErrorResponse "API key not found"
+// @Failure 500 {object} types.ErrorResponse "Failed to revoke the API key"
+// @Router /api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	log.Println("RevokeAPIKey: Received request to revoke an API key")
+	keyID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || keyID <= 0 {
+		log.Println("RevokeAPIKey: Invalid API key ID")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid API key ID"})
+		return
+	}
+
+	if err := h.APIKeyService.RevokeAPIKey(uint(keyID)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Println("RevokeAPIKey: API key not found")
+			c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
+			return
+		}
+		log.Println("RevokeAPIKey: Failed to revoke API key")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke API key"})
+		return
+	}
+
+	log.Printf("RevokeAPIKey: API key %d revoked", keyID)
+	c.JSON(http.StatusOK, gin.H{"message": "API key revoked successfully"})
+}
@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/services"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"gorm.io/gorm"
+)
+
+type ArtistHandler struct {
+	ArtistService services.ArtistServicer
+}
+
+func NewArtistHandler(artistService services.ArtistServicer) *ArtistHandler {
+	return &ArtistHandler{ArtistService: artistService}
+}
+
+// AddArtist godoc
+// @Summary Add a new artist
+// @Description Creates an artist. Names are unique ignoring case, spacing and a leading "The".
+// @Tags Artists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param artist body types.ArtistRequest true "Artist to create"
+// @Success 201 {object} models.Artist "The created artist"
+// @Failure 400 {object} types.ErrorResponse "Invalid request payload"
+// @Failure 409 {object} types.ErrorResponse "Artist already exists"
+// @Failure 500 {object} types.ErrorResponse "Failed to add the artist"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Insufficient permissions"
+// @Router /artists [post]
+func (h *ArtistHandler) AddArtist(c *gin.Context) {
+	log.Println("AddArtist: Received request to add an artist")
+	var req types.ArtistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("AddArtist: Invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: 'name' is required"})
+		return
+	}
+
+	artist := &models.Artist{}
+	applyArtistRequest(artist, req)
+
+	log.Printf("AddArtist: Adding artist '%s'", artist.Name)
+	if err := h.ArtistService.AddArtist(artist); err != nil {
+		if errors.Is(err, services.ErrArtistExists) {
+			log.Println("AddArtist: Artist already exists")
+			c.JSON(http.StatusConflict, gin.H{"error": "Artist already exists"})
+			return
+		}
+		log.Println("AddArtist: Failed to add artist")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add artist"})
+		return
+	}
+
+	log.Println("AddArtist: Artist added successfully")
+	c.JSON(http.StatusCreated, gin.H{"message": "Artist added successfully", "artist": artist})
+}
+
+// GetArtist godoc
+// @Summary Retrieve an artist
+// @Description Fetches an artist by its ID
+// @Tags Artists
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "The ID of the artist"
+// @Success 200 {object} models.Artist "The requested artist"
+// @Failure 400 {object} types.ErrorResponse "Invalid artist ID"
+// @Failure 404 {object} types.ErrorResponse "Artist not found"
+// @Failure 500 {object} types.ErrorResponse "Failed to fetch the artist"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Router /artists/{id} [get]
+func (h *ArtistHandler) GetArtist(c *gin.Context) {
+	log.Println("GetArtist: Received request to fetch an artist")
+	artist, ok := h.fetchArtist(c, "GetArtist")
+	if !ok {
+		return
+	}
+
+	log.Println("GetArtist: Artist fetched successfully")
+	c.JSON(http.StatusOK, artist)
+}
+
+// UpdateArtist godoc
+// @Summary Update an artist
+// @Description Updates an existing artist by its ID
+// @Tags Artists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "The ID of the artist to update"
+// @Param artist body types.ArtistRequest true "Updated artist details"
+// @Success 200 {object} models.Artist "The updated artist"
+// @Failure 400 {object} types.ErrorResponse "Invalid artist ID or payload"
+// @Failure 404 {object} types.ErrorResponse "Artist not found"
+// @Failure 409 {object} types.ErrorResponse "Another artist already has this name"
+// @Failure 500 {object} types.ErrorResponse "Failed to update the artist"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Insufficient permissions"
+// @Router /artists/{id} [put]
+func (h *ArtistHandler) UpdateArtist(c *gin.Context) {
+	log.Println("UpdateArtist: Received request to update an artist")
+	artist, ok := h.fetchArtist(c, "UpdateArtist")
+	if !ok {
+		return
+	}
+
+	var req types.ArtistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("UpdateArtist: Invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	applyArtistRequest(artist, req)
+
+	log.Printf("UpdateArtist: Updating artist with ID %d", artist.ID)
+	if err := h.ArtistService.UpdateArtist(artist); err != nil {
+		if errors.Is(err, services.ErrArtistExists) {
+			log.Println("UpdateArtist: Artist name already taken")
+			c.JSON(http.StatusConflict, gin.H{"error": "Artist already exists"})
+			return
+		}
+		log.Println("UpdateArtist: Failed to update artist")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update artist"})
+		return
+	}
+
+	log.Println("UpdateArtist: Artist updated successfully")
+	c.JSON(http.StatusOK, gin.H{"message": "Artist updated successfully", "artist": artist})
+}
+
+// DeleteArtist godoc
+// @Summary Delete an artist
+// @Description Deletes an artist by its ID. Artists that still have songs or albums cannot be deleted.
+// @Tags Artists
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "The ID of the artist to delete"
+// @Success 200 {object} types.MessageResponse "Deletion success message"
+// @Failure 400 {object} types.ErrorResponse "Invalid artist ID"
+// @Failure 404 {object} types.ErrorResponse "Artist not found"
+// @Failure 409 {object} types.ErrorResponse "Artist still has songs or albums"
+// @Failure 500 {object} types.ErrorResponse "Failed to delete the artist"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Insufficient permissions"
+// @Router /artists/{id} [delete]
+func (h *ArtistHandler) DeleteArtist(c *gin.Context) {
+	log.Println("DeleteArtist: Received request to delete an artist")
+	artist, ok := h.fetchArtist(c, "DeleteArtist")
+	if !ok {
+		return
+	}
+
+	log.Printf("DeleteArtist: Deleting artist with ID %d", artist.ID)
+	if err := h.ArtistService.DeleteArtist(artist.ID); err != nil {
+		if errors.Is(err, services.ErrArtistHasSongs) {
+			log.Println("DeleteArtist: Artist still has songs")
+			c.JSON(http.StatusConflict, gin.H{"error": "Artist still has songs"})
+			return
+		}
+		if errors.Is(err, services.ErrArtistHasAlbums) {
+			log.Println("DeleteArtist: Artist still has albums")
+			c.JSON(http.StatusConflict, gin.H{"error": "Artist still has albums"})
+			return
+		}
+		log.Println("DeleteArtist: Failed to delete artist")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete artist"})
+		return
+	}
+
+	log.Println("DeleteArtist: Artist deleted successfully")
+	c.JSON(http.StatusOK, gin.H{"message": "Artist deleted successfully"})
+}
+
+// ListArtists godoc
+// @Summary List all artists
+// @Description Retrieves a paginated list of artists ordered by sort name
+// @Tags Artists
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Number of artists per page (default: 10)"
+// @Success 200 {object} types.PaginatedArtistsResponse "Paginated list of artists"
+// @Failure 500 {object} types.ErrorResponse "Failed to fetch the list of artists"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Router /artists [get]
+func (h *ArtistHandler) ListArtists(c *gin.Context) {
+	log.Println("ListArtists: Received request to list artists")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	artists, totalArtists, err := h.ArtistService.ListArtists(limit, (page-1)*limit)
+	if err != nil {
+		log.Println("ListArtists: Failed to list artists")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list artists"})
+		return
+	}
+
+	log.Println("ListArtists: Artists listed successfully")
+	c.JSON(http.StatusOK, types.PaginatedArtistsResponse{
+		Page:         page,
+		Limit:        limit,
+		TotalPages:   (totalArtists + limit - 1) / limit,
+		TotalArtists: totalArtists,
+		Data:         artists,
+	})
+}
+
+// fetchArtist resolves the :id path parameter and writes the error response itself.
+func (h *ArtistHandler) fetchArtist(c *gin.Context, op string) (*models.Artist, bool) {
+	artistID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || artistID <= 0 {
+		log.Printf("%s: Invalid artist ID", op)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid artist ID"})
+		return nil, false
+	}
+
+	artist, err := h.ArtistService.GetArtistByID(uint(artistID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("%s: Artist not found", op)
+			c.JSON(http.StatusNotFound, gin.H{"error": "Artist not found"})
+			return nil, false
+		}
+		log.Printf("%s: Failed to fetch artist", op)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch artist"})
+		return nil, false
+	}
+
+	return artist, true
+}
+
+func applyArtistRequest(artist *models.Artist, req types.ArtistRequest) {
+	artist.Name = req.Name
+	artist.SortName = req.SortName
+	artist.Country = req.Country
+	artist.FormedYear = req.FormedYear
+	artist.Biography = req.Biography
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srmbackisdeveloper/test-music-info/internal/services"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+)
+
+type AuthHandler struct {
+	AuthService services.AuthServicer
+}
+
+func NewAuthHandler(authService services.AuthServicer) *AuthHandler {
+	return &AuthHandler{AuthService: authService}
+}
+
+// Login godoc
+// @Summary Log in
+// @Description Exchanges a username and password for a signed JWT to send as "Authorization: Bearer <token>"
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param credentials body types.LoginRequest true "Username and password"
+// @Success 200 {object} types.LoginResponse "Signed token and its expiry"
+// @Failure 400 {object} types.ErrorResponse "Invalid request payload"
+// @Failure 401 {object} types.ErrorResponse "Invalid username or password"
+// @Failure 500 {object} types.ErrorResponse "Failed to log in"
+// @Router /auth/login [post]
+func (h *AuthHandler) Login(c *gin.Context) {
+	log.Println("Login: Received login request")
+	var req types.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("Login: Invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: 'username' and 'password' are required"})
+		return
+	}
+
+	token, expiresAt, err := h.AuthService.Login(req.Username, req.Password)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCredentials) {
+			log.Printf("Login: Invalid credentials for '%s'", req.Username)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid username or password"})
+			return
+		}
+		log.Println("Login: Failed to log in")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log in"})
+		return
+	}
+
+	log.Printf("Login: User '%s' logged in", req.Username)
+	c.JSON(http.StatusOK, types.LoginResponse{Token: token, ExpiresAt: expiresAt})
+}
+
+// AddUser godoc
+// @Summary Create a user
+// @Description Creates a user account with a role (admin only)
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param user body types.AddUserRequest true "User to create"
+// @Success 201 {object} models.User "The created user"
+// @Failure 400 {object} types.ErrorResponse "Invalid request payload"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Insufficient permissions"
+// @Failure 409 {object} types.ErrorResponse "Username already taken"
+// @Failure 500 {object} types.ErrorResponse "Failed to create the user"
+// @Router /users [post]
+func (h *AuthHandler) AddUser(c *gin.Context) {
+	log.Println("AddUser: Received request to create a user")
+	var req types.AddUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("AddUser: Invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: 'username', 'password' (8+ characters) and 'role' (viewer, editor or admin) are required"})
+		return
+	}
+
+	user, err := h.AuthService.AddUser(req.Username, req.Password, req.Role)
+	if err != nil {
+		if errors.Is(err, services.ErrUserExists) {
+			log.Println("AddUser: Username already taken")
+			c.JSON(http.StatusConflict, gin.H{"error": "Username already taken"})
+			return
+		}
+		log.Println("AddUser: Failed to create user")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user"})
+		return
+	}
+
+	log.Printf("AddUser: User '%s' created with role '%s'", user.Username, user.Role)
+	c.JSON(http.StatusCreated, gin.H{"message": "User created successfully", "user": user})
+}
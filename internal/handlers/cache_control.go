@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+)
+
+// DefaultCacheControl makes clients revalidate every time, which conditional
+// GETs turn into cheap 304 responses.
+const DefaultCacheControl = "private, no-cache"
+
+// ParseCacheControlRoutes parses a semicolon separated list of
+// "<METHOD> <path>=<Cache-Control>", e.g.
+// "GET /lyrics/:id=private, max-age=300;GET /info=no-store". Paths are the
+// router's patterns; semicolons separate routes because Cache-Control
+// directives are themselves separated by commas.
+func ParseCacheControlRoutes(value string) (map[string]string, error) {
+	routes := map[string]string{}
+	for _, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		route, policy, found := strings.Cut(entry, "=")
+		route = strings.Join(strings.Fields(route), " ")
+		policy = strings.TrimSpace(policy)
+		if !found || len(strings.Fields(route)) != 2 || policy == "" {
+			return nil, fmt.Errorf("invalid route cache policy %q: expected <METHOD> <path>=<Cache-Control>", entry)
+		}
+		routes[route] = policy
+	}
+	return routes, nil
+}
+
+// checkNotModified sends the validators of song, its ETag and its UpdatedAt
+// as Last-Modified, with the Cache-Control policy of the route. It
+// answers 304 and returns true when the client's copy is still current. As in
+// RFC 9110, If-None-Match takes precedence over If-Modified-Since and compares
+// weakly.
+func (h *MusicHandler) checkNotModified(c *gin.Context, song *models.Music) bool {
+	setSongETag(c, song)
+	lastModified := song.UpdatedAt.UTC().Truncate(time.Second)
+	if !song.UpdatedAt.IsZero() {
+		c.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+	}
+	c.Header("Cache-Control", h.cacheControl(c))
+
+	if header := c.GetHeader("If-None-Match"); header != "" {
+		etag := songETag(song)
+		for _, tag := range strings.Split(header, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == etag {
+				c.Status(http.StatusNotModified)
+				return true
+			}
+		}
+		return false
+	}
+
+	if header := c.GetHeader("If-Modified-Since"); header != "" && !song.UpdatedAt.IsZero() {
+		since, err := http.ParseTime(header)
+		if err == nil && !lastModified.After(since) {
+			c.Status(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// cacheControl is the Cache-Control policy configured for the route of c.
+func (h *MusicHandler) cacheControl(c *gin.Context) string {
+	if policy, ok := h.CacheControl[c.Request.Method+" "+c.FullPath()]; ok {
+		return policy
+	}
+	if h.DefaultCacheControl != "" {
+		return h.DefaultCacheControl
+	}
+	return DefaultCacheControl
+}
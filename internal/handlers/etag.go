@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+)
+
+// songETag is the strong entity tag of a song: its ID and version, quoted.
+// The ID keeps a song that replaced another under the same group and title
+// from matching the tags of the old one, whose versions it repeats.
+func songETag(song *models.Music) string {
+	return strconv.Quote(fmt.Sprintf("%d-%d", song.ID, song.Version))
+}
+
+// setSongETag sends the entity tag of song, for the client to pass back as
+// If-Match when it changes the song.
+func setSongETag(c *gin.Context, song *models.Music) {
+	c.Header("ETag", songETag(song))
+}
+
+// checkIfMatch compares the If-Match header of a write with the current
+// version of song. When no tag in it matches it answers 412, and when the
+// header is missing while RequireIfMatch is set it answers 428; it returns
+// false in both cases. Weak tags never match, as If-Match compares strongly.
+func (h *MusicHandler) checkIfMatch(c *gin.Context, song *models.Music) bool {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		if h.RequireIfMatch {
+			c.JSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match header with the ETag of the song is required"})
+			return false
+		}
+		return true
+	}
+
+	etag := songETag(song)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	writePreconditionFailed(c)
+	return false
+}
+
+// writePreconditionFailed answers 412 for a write based on an outdated
+// version of a song.
+func writePreconditionFailed(c *gin.Context) {
+	c.JSON(http.StatusPreconditionFailed, gin.H{"error": "Song was changed since it was read; fetch it again and retry"})
+}
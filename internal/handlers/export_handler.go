@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+)
+
+// exportFlushEvery is how many songs are written between flushes of the
+// chunked response.
+const exportFlushEvery = 100
+
+// exportCSVHeader lists the CSV columns, which POST /music/import reads back.
+var exportCSVHeader = []string{"id", "group", "title", "releaseDate", "text", "link"}
+
+// songEncoder writes songs in one export format.
+type songEncoder struct {
+	contentType string
+	extension   string
+	begin       func(w io.Writer) error
+	encode      func(w io.Writer, song models.Music, first bool) error
+	end         func(w io.Writer) error
+}
+
+var songEncoders = map[string]songEncoder{
+	"csv": {
+		contentType: "text/csv; charset=utf-8",
+		extension:   "csv",
+		begin: func(w io.Writer) error {
+			return writeCSVRecord(w, exportCSVHeader)
+		},
+		encode: func(w io.Writer, song models.Music, first bool) error {
+			releaseDate := ""
+			if !song.ReleaseDate.IsZero() {
+				releaseDate = song.ReleaseDate.Format("2006-01-02")
+			}
+			return writeCSVRecord(w, []string{
+				strconv.FormatUint(uint64(song.ID), 10), song.Group, song.Title, releaseDate, song.Text, song.Link,
+			})
+		},
+		end: func(w io.Writer) error { return nil },
+	},
+	"json": {
+		contentType: "application/json; charset=utf-8",
+		extension:   "json",
+		begin: func(w io.Writer) error {
+			_, err := io.WriteString(w, "[")
+			return err
+		},
+		encode: func(w io.Writer, song models.Music, first bool) error {
+			if !first {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			return writeJSON(w, song)
+		},
+		end: func(w io.Writer) error {
+			_, err := io.WriteString(w, "]")
+			return err
+		},
+	},
+	"ndjson": {
+		contentType: "application/x-ndjson",
+		extension:   "ndjson",
+		begin:       func(w io.Writer) error { return nil },
+		encode: func(w io.Writer, song models.Music, first bool) error {
+			if err := writeJSON(w, song); err != nil {
+				return err
+			}
+			_, err := io.WriteString(w, "\n")
+			return err
+		},
+		end: func(w io.Writer) error { return nil },
+	},
+}
+
+func writeCSVRecord(w io.Writer, record []string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(record); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// ExportSongs godoc
+// @Summary Export songs
+// @Description Streams every song matching the filters, ordered by ID, as CSV, a JSON array or NDJSON. The response is chunked and read from a database cursor, so the whole catalog can be exported at once. The CSV columns match the ones POST /music/import reads.
+// @Tags Songs
+// @Produce text/csv,json,application/x-ndjson
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param format query string false "Export format (default: json)" Enums(csv, json, ndjson)
+// @Param group query string false "Filter by group name (case-insensitive substring)"
+// @Param title query string false "Filter by song title (case-insensitive substring)"
+// @Param artistId query int false "Filter by artist ID"
+// @Param releasedFrom query string false "Earliest release date, as 2006-01-02"
+// @Param releasedTo query string false "Latest release date, as 2006-01-02"
+// @Param hasLyrics query bool false "Only songs with (true) or without (false) lyrics"
+// @Param hasLink query bool false "Only songs with (true) or without (false) a link"
+// @Success 200 {array} models.Music "The matching songs"
+// @Failure 400 {object} types.ErrorResponse "Unknown format or invalid filter"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Insufficient permissions"
+// @Failure 500 {object} types.ErrorResponse "Failed to export the songs"
+// @Router /music/export [get]
+func (h *MusicHandler) ExportSongs(c *gin.Context) {
+	log.Println("ExportSongs: Received request to export songs")
+	format := strings.ToLower(c.DefaultQuery("format", "json"))
+	encoder, ok := songEncoders[format]
+	if !ok {
+		log.Println("ExportSongs: Unknown format")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown format: use csv, json or ndjson"})
+		return
+	}
+
+	filter, err := songFilter(c)
+	if err != nil {
+		log.Printf("ExportSongs: Invalid filter: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// The headers go out with the first song, so that a failure before it can
+	// still be reported as an error response.
+	exported := 0
+	start := func() error {
+		c.Header("Content-Type", encoder.contentType)
+		c.Header("Content-Disposition", `attachment; filename="songs.`+encoder.extension+`"`)
+		c.Status(http.StatusOK)
+		return encoder.begin(c.Writer)
+	}
+
+	log.Printf("ExportSongs: Exporting songs with filter %+v as %s", filter, format)
+	err = h.MusicService.ExportSongs(filter, func(song models.Music) error {
+		if exported == 0 {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		if err := encoder.encode(c.Writer, song, exported == 0); err != nil {
+			return err
+		}
+		exported++
+		if exported%exportFlushEvery == 0 {
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err == nil && exported == 0 {
+		err = start()
+	}
+	if err == nil {
+		err = encoder.end(c.Writer)
+	}
+	if err != nil {
+		if !c.Writer.Written() {
+			log.Printf("ExportSongs: Failed to export songs: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export songs"})
+			return
+		}
+		// The status is already sent; breaking the connection is all that is
+		// left, so the client does not take the truncated file as complete.
+		log.Printf("ExportSongs: Export aborted after %d songs: %v", exported, err)
+		abortStream(c)
+		return
+	}
+
+	c.Writer.Flush()
+	log.Printf("ExportSongs: %d songs exported successfully", exported)
+}
+
+// abortStream closes the connection of a streamed response without ending its
+// chunked body, which clients report as an unexpected EOF. Gin's recovery
+// would swallow a panic with http.ErrAbortHandler, so the connection is
+// hijacked instead.
+func abortStream(c *gin.Context) {
+	c.Abort()
+	c.Writer.Flush()
+
+	writer := http.ResponseWriter(c.Writer)
+	if unwrapper, ok := writer.(interface{ Unwrap() http.ResponseWriter }); ok {
+		writer = unwrapper.Unwrap()
+	}
+	conn, _, err := http.NewResponseController(writer).Hijack()
+	if err != nil {
+		log.Printf("ExportSongs: Failed to break the connection: %v", err)
+		return
+	}
+	conn.Close()
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srmbackisdeveloper/test-music-info/internal/middleware"
+	"github.com/srmbackisdeveloper/test-music-info/internal/services"
+)
+
+// maxImportSize caps the size of an import upload.
+const maxImportSize = 64 << 20
+
+// importContentTypes maps upload content types to import formats.
+var importContentTypes = map[string]string{
+	"text/csv":             services.ImportFormatCSV,
+	"application/csv":      services.ImportFormatCSV,
+	"application/json":     services.ImportFormatJSON,
+	"application/x-ndjson": services.ImportFormatNDJSON,
+	"application/ndjson":   services.ImportFormatNDJSON,
+	"application/jsonl":    services.ImportFormatNDJSON,
+}
+
+// ImportSongs godoc
+// @Summary Import songs
+// @Description Creates or updates songs in bulk from a CSV (with a header row), JSON array or NDJSON upload using the song fields group, title, releaseDate (2006-01-02), text and link. Songs are matched by group and title: new ones are created, existing ones updated, and empty fields keep their stored value. Invalid rows are reported and left out; the rest are written in batches, each in its own transaction. The upload is either the request body or a multipart "file" field. The format is taken from the format parameter, else from the content type or file extension.
+// @Tags Songs
+// @Accept text/csv,json,application/x-ndjson,mpfd
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param format query string false "Upload format" Enums(csv, json, ndjson)
+// @Param dryRun query bool false "Report what would be created, updated and skipped without writing anything"
+// @Param file formData file false "The file to import, for multipart uploads"
+// @Success 200 {object} types.ImportResult "Outcome of every row"
+// @Failure 400 {object} types.ErrorResponse "Unknown format or unreadable upload"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Insufficient permissions"
+// @Failure 500 {object} types.ErrorResponse "Failed to import the songs"
+// @Router /music/import [post]
+func (h *MusicHandler) ImportSongs(c *gin.Context) {
+	log.Println("ImportSongs: Received request to import songs")
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dryRun", "false"))
+	if err != nil {
+		log.Println("ImportSongs: Invalid dryRun parameter")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "'dryRun' must be true or false"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+	upload, format, err := importUpload(c)
+	if err != nil {
+		log.Printf("ImportSongs: Invalid upload: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer upload.Close()
+
+	rows, err := services.ParseImport(upload, format)
+	if err != nil {
+		log.Printf("ImportSongs: Failed to read upload: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Printf("ImportSongs: Importing %d %s rows (dry run: %t)", len(rows), format, dryRun)
+	result, err := h.MusicService.ImportSongs(rows, dryRun, middleware.CurrentSubject(c))
+	if err != nil {
+		log.Printf("ImportSongs: Failed to import songs: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import songs"})
+		return
+	}
+
+	log.Printf("ImportSongs: %d created, %d updated, %d skipped, %d failed", result.Created, result.Updated, result.Skipped, result.Failed)
+	c.JSON(http.StatusOK, result)
+}
+
+// importUpload returns the uploaded file and its format.
+func importUpload(c *gin.Context) (io.ReadCloser, string, error) {
+	format := strings.ToLower(c.Query("format"))
+	mediaType, _, _ := mime.ParseMediaType(c.ContentType())
+
+	if mediaType == "multipart/form-data" {
+		header, err := c.FormFile("file")
+		if err != nil {
+			return nil, "", errors.New("multipart uploads need a 'file' field")
+		}
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), ".")
+			if format == "jsonl" {
+				format = services.ImportFormatNDJSON
+			}
+		}
+		if err := checkImportFormat(format); err != nil {
+			return nil, "", err
+		}
+		file, err := header.Open()
+		if err != nil {
+			return nil, "", err
+		}
+		return file, format, nil
+	}
+
+	if format == "" {
+		format = importContentTypes[mediaType]
+	}
+	if err := checkImportFormat(format); err != nil {
+		return nil, "", err
+	}
+	return c.Request.Body, format, nil
+}
+
+func checkImportFormat(format string) error {
+	switch format {
+	case services.ImportFormatCSV, services.ImportFormatJSON, services.ImportFormatNDJSON:
+		return nil
+	case "":
+		return errors.New("could not tell the upload format; pass format=csv, json or ndjson")
+	default:
+		return errors.New("unknown format " + strconv.Quote(format) + "; use csv, json or ndjson")
+	}
+}
@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srmbackisdeveloper/test-music-info/internal/middleware"
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/services"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"gorm.io/gorm"
+)
+
+const (
+	lyricsFormatLRC  = "lrc"
+	lyricsFormatJSON = "json"
+)
+
+// maxLRCSize caps the size of an uploaded LRC file.
+const maxLRCSize = 1 << 20
+
+// writeSyncedLyrics responds with the song's synchronized lyrics, which must
+// not be empty, as an LRC file or as JSON.
+func writeSyncedLyrics(c *gin.Context, song *models.Music, format string) {
+	log.Printf("GetLyrics: Returning %d synchronized lines as %s", len(song.SyncedLyrics), format)
+	if format == lyricsFormatLRC {
+		c.String(http.StatusOK, song.SyncedLyrics.String())
+		return
+	}
+	c.JSON(http.StatusOK, types.SyncedLyricsResponse{SongID: song.ID, Lines: song.SyncedLyrics})
+}
+
+// UploadLyrics godoc
+// @Summary Upload synchronized lyrics
+// @Description Replaces the synchronized lyrics of a song with an LRC file, sent as the request body or as a multipart "file" field. Every line needs a [mm:ss.xx] timestamp; ID tags are allowed and [offset:] is applied. The plain text lyrics are left unchanged. Send the ETag of the song as If-Match to replace them only if nobody changed the song since it was read; the response carries the new ETag.
+// @Tags Songs
+// @Accept plain,mpfd
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "The ID of the song"
+// @Param file formData file false "The LRC file, for multipart uploads"
+// @Param If-Match header string false "ETag of the song the upload is based on; required when the server enforces it"
+// @Success 200 {object} models.Music "The updated song"
+// @Failure 400 {object} types.ErrorResponse "Invalid song ID or LRC file"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Insufficient permissions"
+// @Failure 404 {object} types.ErrorResponse "Song not found"
+// @Failure 412 {object} types.ErrorResponse "The song was changed since the ETag in If-Match"
+// @Failure 413 {object} types.ErrorResponse "LRC file larger than 1 MiB"
+// @Failure 428 {object} types.ErrorResponse "If-Match is required"
+// @Failure 500 {object} types.ErrorResponse "Failed to save the lyrics"
+// @Router /lyrics/{id} [put]
+func (h *MusicHandler) UploadLyrics(c *gin.Context) {
+	log.Println("UploadLyrics: Received request to upload synchronized lyrics")
+	songID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || songID <= 0 {
+		log.Println("UploadLyrics: Invalid song ID")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid song ID"})
+		return
+	}
+
+	lrc, err := readLRCUpload(c)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			log.Println("UploadLyrics: LRC file too large")
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "The LRC file must not exceed 1 MiB"})
+			return
+		}
+		log.Printf("UploadLyrics: Invalid upload: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	current, err := h.MusicService.GetSongByID(uint(songID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Println("UploadLyrics: Song not found")
+			c.JSON(http.StatusNotFound, gin.H{"error": "Song not found"})
+			return
+		}
+		log.Println("UploadLyrics: Failed to fetch song")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch song"})
+		return
+	}
+	if !h.checkIfMatch(c, current) {
+		log.Printf("UploadLyrics: If-Match does not hold for song %d at version %d", songID, current.Version)
+		return
+	}
+
+	song, err := h.MusicService.SetSyncedLyrics(uint(songID), current.Version, lrc, middleware.CurrentSubject(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrVersionConflict):
+			log.Printf("UploadLyrics: Song %d changed while its lyrics were being saved", songID)
+			writePreconditionFailed(c)
+		case errors.Is(err, services.ErrInvalidLRC):
+			log.Printf("UploadLyrics: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			log.Println("UploadLyrics: Song not found")
+			c.JSON(http.StatusNotFound, gin.H{"error": "Song not found"})
+		default:
+			log.Println("UploadLyrics: Failed to save lyrics")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save lyrics"})
+		}
+		return
+	}
+
+	log.Printf("UploadLyrics: %d synchronized lines saved for song %d", len(song.SyncedLyrics), songID)
+	setSongETag(c, song)
+	c.JSON(http.StatusOK, gin.H{"message": "Lyrics uploaded successfully", "song": song})
+}
+
+func readLRCUpload(c *gin.Context) (string, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxLRCSize)
+	body := io.Reader(c.Request.Body)
+
+	mediaType, _, _ := mime.ParseMediaType(c.ContentType())
+	if mediaType == "multipart/form-data" {
+		header, err := c.FormFile("file")
+		if errors.Is(err, http.ErrMissingFile) {
+			return "", errors.New("multipart uploads need a 'file' field")
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read the multipart upload: %w", err)
+		}
+		file, err := header.Open()
+		if err != nil {
+			return "", err
+		}
+		defer file.Close()
+		body = file
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the LRC file: %w", err)
+	}
+	return string(data), nil
+}
+
+// GetActiveLyricLine godoc
+// @Summary Get the lyric line at a playback position
+// @Description Returns the synchronized lyric line being sung at the given position, and the line that follows it
+// @Tags Songs
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "The ID of the song"
+// @Param position query string true "Playback position, in seconds (83.5) or as a duration (1m23.5s)"
+// @Success 200 {object} types.ActiveLyricLineResponse "The active line"
+// @Failure 400 {object} types.ErrorResponse "Invalid song ID or position"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 404 {object} types.ErrorResponse "Song not found, or it has no synchronized lyrics"
+// @Failure 500 {object} types.ErrorResponse "Failed to fetch the lyrics"
+// @Router /lyrics/{id}/line [get]
+func (h *MusicHandler) GetActiveLyricLine(c *gin.Context) {
+	log.Println("GetActiveLyricLine: Received request for the active lyric line")
+	songID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || songID <= 0 {
+		log.Println("GetActiveLyricLine: Invalid song ID")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid song ID"})
+		return
+	}
+
+	position, err := parsePlaybackPosition(c.Query("position"))
+	if err != nil {
+		log.Println("GetActiveLyricLine: Invalid position")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "'position' must be a non-negative number of seconds or a duration such as 1m23.5s"})
+		return
+	}
+
+	song, err := h.MusicService.GetSongByID(uint(songID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Println("GetActiveLyricLine: Song not found")
+			c.JSON(http.StatusNotFound, gin.H{"error": "Song not found"})
+			return
+		}
+		log.Println("GetActiveLyricLine: Failed to fetch song")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve song"})
+		return
+	}
+	if len(song.SyncedLyrics) == 0 {
+		log.Println("GetActiveLyricLine: Song has no synchronized lyrics")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Song has no synchronized lyrics"})
+		return
+	}
+
+	positionMs := position.Milliseconds()
+	response := types.ActiveLyricLineResponse{SongID: song.ID, PositionMs: positionMs}
+	response.Index = song.SyncedLyrics.ActiveAt(positionMs)
+	if response.Index >= 0 {
+		response.Line = &song.SyncedLyrics[response.Index]
+	}
+	if response.Index+1 < len(song.SyncedLyrics) {
+		response.Next = &song.SyncedLyrics[response.Index+1]
+	}
+
+	log.Printf("GetActiveLyricLine: Line %d is active at %dms", response.Index, positionMs)
+	c.JSON(http.StatusOK, response)
+}
+
+// parsePlaybackPosition reads a position given in seconds or as a duration.
+func parsePlaybackPosition(value string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		if seconds < 0 || math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+			return 0, errors.New("negative position")
+		}
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+
+	position, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if position < 0 {
+		return 0, errors.New("negative position")
+	}
+	return position, nil
+}
@@ -2,12 +2,15 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/srmbackisdeveloper/test-music-info/internal/middleware"
 	"github.com/srmbackisdeveloper/test-music-info/internal/models"
 	"github.com/srmbackisdeveloper/test-music-info/internal/services"
 	"github.com/srmbackisdeveloper/test-music-info/internal/types"
@@ -15,23 +18,44 @@ import (
 )
 
 type MusicHandler struct {
-	MusicService *services.MusicService
+	MusicService services.MusicServicer
+	// RequireIfMatch makes writes to an existing song (PUT, PATCH and DELETE
+	// on /music/:id, PUT /music, PUT /lyrics/:id and revision restores) fail
+	// with 428 unless they carry an If-Match header.
+	RequireIfMatch bool
+	// CacheControl is the Cache-Control policy of GET /info, /music/:id and
+	// /lyrics/:id, keyed by "<METHOD> <path>"; routes without one get
+	// DefaultCacheControl.
+	CacheControl        map[string]string
+	DefaultCacheControl string
 }
 
-func NewMusicHandler(musicService *services.MusicService) *MusicHandler {
-	return &MusicHandler{MusicService: musicService}
+func NewMusicHandler(musicService services.MusicServicer) *MusicHandler {
+	return &MusicHandler{
+		MusicService:        musicService,
+		CacheControl:        map[string]string{},
+		DefaultCacheControl: DefaultCacheControl,
+	}
 }
 
 // AddSong godoc
 // @Summary Add a new song
-// @Description Adds a new song to the database
+// @Description Adds a new song to the database. Group and title must not match an existing song after normalization; the 409 response carries the ID of the one that does.
 // @Tags Songs
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param song body types.AddSongRequest true "Request to add a song"
 // @Success 201 {object} models.Music "The added song"
 // @Failure 400 {object} types.ErrorResponse "Invalid request payload"
+// @Failure 404 {object} types.ErrorResponse "Song details not found in the upstream API"
+// @Failure 409 {object} types.SongConflictResponse "A song with the same group and title exists"
 // @Failure 500 {object} types.ErrorResponse "Failed to add the song to the database"
+// @Failure 502 {object} types.ErrorResponse "Song details API is unavailable or returned an invalid response"
+// @Failure 504 {object} types.ErrorResponse "Song details API timed out"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Insufficient permissions"
 // @Router /music [post]
 func (h *MusicHandler) AddSong(c *gin.Context) {
 	log.Println("AddSong: Received request to add a song")
@@ -48,28 +72,54 @@ func (h *MusicHandler) AddSong(c *gin.Context) {
 		Title: req.Title,
 	}
 
-	err := h.MusicService.AddSong(newSong)
+	err := h.MusicService.AddSong(newSong, middleware.CurrentSubject(c))
 	if err != nil {
-		log.Println("AddSong: Failed to add song")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add song"})
+		switch {
+		case errors.Is(err, services.ErrSongExists):
+			log.Printf("AddSong: %v", err)
+			writeSongConflict(c, err)
+		case errors.Is(err, services.ErrSongDetailNotFound):
+			log.Println("AddSong: Song details not found upstream")
+			c.JSON(http.StatusNotFound, gin.H{"error": "Song details not found"})
+		case errors.Is(err, services.ErrSongDetailTimeout):
+			log.Printf("AddSong: Song details API timed out: %v", err)
+			c.JSON(http.StatusGatewayTimeout, gin.H{"error": "Song details service timed out"})
+		case errors.Is(err, services.ErrSongDetailUnavailable), errors.Is(err, services.ErrSongDetailBadResponse):
+			log.Printf("AddSong: Song details API failed: %v", err)
+			c.JSON(http.StatusBadGateway, gin.H{"error": "Song details service is unavailable"})
+		default:
+			log.Println("AddSong: Failed to add song")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add song"})
+		}
 		return
 	}
 
 	log.Println("AddSong: Song added successfully")
+	setSongETag(c, newSong)
 	c.JSON(http.StatusCreated, gin.H{"message": "Song added successfully", "song": newSong})
 }
 
 // GetSong godoc
 // @Summary Retrieve a song
-// @Description Fetches a song by its group and title
+// @Description Fetches a song by its group and title. Both are compared after normalization, so case, accents, punctuation and extra whitespace do not matter. When no song matches, the 404 response suggests the closest ones. The response carries ETag and Last-Modified; sending them back as If-None-Match or If-Modified-Since returns 304 while the song is unchanged.
 // @Tags Songs
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param group query string true "The group of the song"
 // @Param song query string true "The title of the song"
+// @Param If-None-Match header string false "ETag of the copy the client has"
+// @Param If-Modified-Since header string false "Last-Modified of the copy the client has"
 // @Success 200 {object} types.SongDetail "The requested song"
+// @Header 200 {string} ETag "The version of the song"
+// @Header 200 {string} Last-Modified "When the song was last changed"
+// @Header 200 {string} Cache-Control "The caching policy of the route"
+// @Success 304 {string} string "The client's copy is current"
 // @Failure 400 {object} types.ErrorResponse "Invalid or missing query parameters"
-// @Failure 404 {object} types.ErrorResponse "Song not found"
+// @Failure 404 {object} types.SongNotFoundResponse "Song not found, with suggestions"
+// @Failure 500 {object} types.ErrorResponse "Failed to fetch the song"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
 // @Router /info [get]
 func (h *MusicHandler) GetSong(c *gin.Context) {
 	log.Println("GetSong: Received request to fetch a song")
@@ -85,27 +135,106 @@ func (h *MusicHandler) GetSong(c *gin.Context) {
 	log.Printf("GetSong: Fetching song with group '%s' and title '%s'", group, song)
 	gotSong, err := h.MusicService.GetSong(group, song)
 	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("GetSong: Failed to fetch song: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch song"})
+			return
+		}
+
 		log.Println("GetSong: Song not found")
-		c.JSON(http.StatusNotFound, gin.H{"error": "Song not found"})
+		suggestions, err := h.MusicService.SuggestSongs(group, song)
+		if err != nil {
+			log.Printf("GetSong: Failed to suggest songs: %v", err)
+			suggestions = []types.SongSuggestion{}
+		}
+		c.JSON(http.StatusNotFound, types.SongNotFoundResponse{Error: "Song not found", Suggestions: suggestions})
+		return
+	}
+
+	if h.checkNotModified(c, gotSong) {
+		log.Println("GetSong: Song not modified")
 		return
 	}
 
 	log.Println("GetSong: Song fetched successfully")
-	c.JSON(http.StatusOK, gotSong)
+	c.JSON(http.StatusOK, types.SongDetail{
+		ReleaseDate: gotSong.ReleaseDate.Format("2006-01-02"),
+		Text:        gotSong.Text,
+		Link:        gotSong.Link,
+	})
+}
+
+// GetSongByID godoc
+// @Summary Retrieve a song by ID
+// @Description Fetches a song by its ID. The ETag header carries its ID and version, to send back as If-Match when changing it, or as If-None-Match to get 304 while the song is unchanged; If-Modified-Since works with Last-Modified the same way.
+// @Tags Songs
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "The ID of the song"
+// @Param If-None-Match header string false "ETag of the copy the client has"
+// @Param If-Modified-Since header string false "Last-Modified of the copy the client has"
+// @Success 200 {object} models.Music "The requested song"
+// @Header 200 {string} ETag "The version of the song"
+// @Header 200 {string} Last-Modified "When the song was last changed"
+// @Header 200 {string} Cache-Control "The caching policy of the route"
+// @Success 304 {string} string "The client's copy is current"
+// @Failure 400 {object} types.ErrorResponse "Invalid song ID"
+// @Failure 404 {object} types.ErrorResponse "Song not found"
+// @Failure 500 {object} types.ErrorResponse "Failed to fetch the song"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Router /music/{id} [get]
+func (h *MusicHandler) GetSongByID(c *gin.Context) {
+	log.Println("GetSongByID: Received request to fetch a song")
+	songID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || songID <= 0 {
+		log.Println("GetSongByID: Invalid song ID")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid song ID"})
+		return
+	}
+
+	song, err := h.MusicService.GetSongByID(uint(songID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Println("GetSongByID: Song not found")
+			c.JSON(http.StatusNotFound, gin.H{"error": "Song not found"})
+			return
+		}
+		log.Println("GetSongByID: Failed to fetch song")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch song"})
+		return
+	}
+
+	if h.checkNotModified(c, song) {
+		log.Println("GetSongByID: Song not modified")
+		return
+	}
+
+	log.Println("GetSongByID: Song fetched successfully")
+	c.JSON(http.StatusOK, song)
 }
 
 // UpdateSong godoc
 // @Summary Update a song
-// @Description Updates an existing song by its ID
+// @Description Updates an existing song by its ID. Send the ETag of the song as If-Match to make sure nobody changed it since it was read; the response carries the new ETag.
 // @Tags Songs
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "The ID of the song to update"
+// @Param If-Match header string false "ETag of the song the update is based on; required when the server enforces it"
 // @Param song body models.Music true "Updated song details"
 // @Success 200 {object} models.Music "The updated song"
+// @Header 200 {string} ETag "The new version of the song"
 // @Failure 400 {object} types.ErrorResponse "Invalid song ID or payload"
 // @Failure 404 {object} types.ErrorResponse "Song not found"
+// @Failure 409 {object} types.SongConflictResponse "Another song has the same group and title"
+// @Failure 412 {object} types.ErrorResponse "The song was changed since the ETag in If-Match"
+// @Failure 428 {object} types.ErrorResponse "If-Match is required"
 // @Failure 500 {object} types.ErrorResponse "Failed to update the song"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Insufficient permissions"
 // @Router /music/{id} [put]
 func (h *MusicHandler) UpdateSong(c *gin.Context) {
 	log.Println("UpdateSong: Received request to update a song")
@@ -130,6 +259,11 @@ func (h *MusicHandler) UpdateSong(c *gin.Context) {
 		return
 	}
 
+	if !h.checkIfMatch(c, existingSong) {
+		log.Printf("UpdateSong: If-Match does not hold for song %d at version %d", songID, existingSong.Version)
+		return
+	}
+
 	var req models.Music
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Println("UpdateSong: Invalid request body")
@@ -138,32 +272,70 @@ func (h *MusicHandler) UpdateSong(c *gin.Context) {
 	}
 
 	log.Printf("UpdateSong: Updating song with ID %d", songID)
+	if !req.ReleaseDate.Equal(existingSong.ReleaseDate) {
+		existingSong.Sources.ReleaseDate = models.SourceManual
+	}
+	if req.Text != existingSong.Text {
+		existingSong.Sources.Text = models.SourceManual
+	}
+	if req.Link != existingSong.Link {
+		existingSong.Sources.Link = models.SourceManual
+	}
 	existingSong.Group = req.Group
 	existingSong.Title = req.Title
 	existingSong.ReleaseDate = req.ReleaseDate
 	existingSong.Text = req.Text
 	existingSong.Link = req.Link
 
-	err = h.MusicService.UpdateSong(existingSong)
+	err = h.MusicService.UpdateSong(existingSong, middleware.CurrentSubject(c))
 	if err != nil {
+		if errors.Is(err, services.ErrSongExists) {
+			log.Printf("UpdateSong: %v", err)
+			writeSongConflict(c, err)
+			return
+		}
+		if errors.Is(err, services.ErrVersionConflict) {
+			log.Printf("UpdateSong: Song %d changed while it was being updated", songID)
+			writePreconditionFailed(c)
+			return
+		}
 		log.Println("UpdateSong: Failed to update song")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update song"})
 		return
 	}
 
 	log.Println("UpdateSong: Song updated successfully")
+	setSongETag(c, existingSong)
 	c.JSON(http.StatusOK, gin.H{"message": "Song updated successfully", "song": existingSong})
 }
 
+// writeSongConflict answers a services.ErrSongExists with 409 and, when it
+// is known, the ID of the song already stored.
+func writeSongConflict(c *gin.Context, err error) {
+	response := types.SongConflictResponse{Error: "A song with this group and title already exists"}
+	var exists *services.SongExistsError
+	if errors.As(err, &exists) {
+		response.SongID = exists.ExistingID
+	}
+	c.JSON(http.StatusConflict, response)
+}
+
 // DeleteSong godoc
 // @Summary Delete a song
-// @Description Deletes a song by its ID
+// @Description Moves a song to the trash. It can be restored until the trash is purged. Send the ETag of the song as If-Match to delete it only if nobody changed it since it was read.
 // @Tags Songs
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "The ID of the song to delete"
+// @Param If-Match header string false "ETag of the song; required when the server enforces it"
 // @Success 200 {object} types.MessageResponse "Deletion success message"
 // @Failure 400 {object} types.ErrorResponse "Invalid song ID"
 // @Failure 404 {object} types.ErrorResponse "Song not found"
+// @Failure 412 {object} types.ErrorResponse "The song was changed since the ETag in If-Match"
+// @Failure 428 {object} types.ErrorResponse "If-Match is required"
 // @Failure 500 {object} types.ErrorResponse "Failed to delete the song"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Insufficient permissions"
 // @Router /music/{id} [delete]
 func (h *MusicHandler) DeleteSong(c *gin.Context) {
 	log.Println("DeleteSong: Received request to delete a song")
@@ -176,7 +348,7 @@ func (h *MusicHandler) DeleteSong(c *gin.Context) {
 	}
 
 	log.Printf("DeleteSong: Checking existence of song with ID %d", songID)
-	_, err = h.MusicService.GetSongByID(uint(songID))
+	song, err := h.MusicService.GetSongByID(uint(songID))
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			log.Println("DeleteSong: Song not found")
@@ -188,9 +360,19 @@ func (h *MusicHandler) DeleteSong(c *gin.Context) {
 		return
 	}
 
+	if !h.checkIfMatch(c, song) {
+		log.Printf("DeleteSong: If-Match does not hold for song %d at version %d", songID, song.Version)
+		return
+	}
+
 	log.Printf("DeleteSong: Deleting song with ID %d", songID)
-	err = h.MusicService.DeleteSong(uint(songID))
+	err = h.MusicService.DeleteSong(uint(songID), song.Version, middleware.CurrentSubject(c))
 	if err != nil {
+		if errors.Is(err, services.ErrVersionConflict) {
+			log.Printf("DeleteSong: Song %d changed while it was being deleted", songID)
+			writePreconditionFailed(c)
+			return
+		}
 		log.Println("DeleteSong: Failed to delete song")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete song"})
 		return
@@ -202,14 +384,25 @@ func (h *MusicHandler) DeleteSong(c *gin.Context) {
 
 // ListSongs godoc
 // @Summary List all songs
-// @Description Retrieves a paginated list of songs with optional filters
+// @Description Retrieves a paginated list of songs with optional filters, ordered by the sort parameter and then by ID. Group and title match case-insensitive substrings; the release date range is inclusive and leaves out songs with an unknown release date. Passing the cursor parameter, empty for the first page, switches from page numbers to cursor pagination: the response is then a types.CursorSongsResponse whose nextCursor and prevCursor are passed back as cursor to move between pages. Cursor pages stay consistent while songs are added or removed.
 // @Tags Songs
-// @Param group query string false "Filter by group name"
-// @Param title query string false "Filter by song title"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param group query string false "Filter by group name (case-insensitive substring)"
+// @Param title query string false "Filter by song title (case-insensitive substring)"
+// @Param artistId query int false "Filter by artist ID"
+// @Param releasedFrom query string false "Earliest release date, as 2006-01-02"
+// @Param releasedTo query string false "Latest release date, as 2006-01-02"
+// @Param hasLyrics query bool false "Only songs with (true) or without (false) lyrics"
+// @Param hasLink query bool false "Only songs with (true) or without (false) a link"
+// @Param sort query string false "Comma-separated sort keys among title, group, releaseDate and createdAt, each optionally followed by :asc or :desc, e.g. group,releaseDate:desc"
 // @Param page query int false "Page number (default: 1)"
 // @Param limit query int false "Number of songs per page (default: 10)"
+// @Param cursor query string false "Cursor from a previous response; empty for the first page"
 // @Success 200 {object} types.PaginatedSongsResponse "Paginated list of songs"
+// @Failure 400 {object} types.ErrorResponse "Invalid filter, sort or cursor"
 // @Failure 500 {object} types.ErrorResponse "Failed to fetch the list of songs"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
 // @Router /music [get]
 func (h *MusicHandler) ListSongs(c *gin.Context) {
 	log.Println("ListSongs: Received request to list songs")
@@ -224,16 +417,45 @@ func (h *MusicHandler) ListSongs(c *gin.Context) {
 	}
 
 	offset := (page - 1) * limit
-	filter := map[string]interface{}{}
-	if group := c.Query("group"); group != "" {
-		filter["group_name"] = group
+	filter, err := songFilter(c)
+	if err != nil {
+		log.Printf("ListSongs: Invalid filter: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
-	if title := c.Query("title"); title != "" {
-		filter["title"] = title
+	sort, err := services.ParseSongSort(c.Query("sort"))
+	if err != nil {
+		log.Printf("ListSongs: Invalid sort: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid sort, expected comma-separated fields among %s, each optionally followed by :asc or :desc", strings.Join(models.SongSortFields, ", "))})
+		return
 	}
 
-	log.Printf("ListSongs: Fetching songs with filter %+v, page %d, limit %d", filter, page, limit)
-	songs, totalSongs, err := h.MusicService.ListSongs(filter, limit, offset)
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		log.Printf("ListSongs: Fetching songs with filter %+v, sort %v, cursor %q, limit %d", filter, sort, cursor, limit)
+		songs, nextCursor, prevCursor, err := h.MusicService.ListSongsByCursor(filter, sort, cursor, limit)
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidCursor) {
+				log.Println("ListSongs: Invalid cursor")
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
+				return
+			}
+			log.Println("ListSongs: Failed to list songs")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list songs"})
+			return
+		}
+
+		log.Println("ListSongs: Songs listed successfully")
+		c.JSON(http.StatusOK, types.CursorSongsResponse{
+			Limit:      limit,
+			NextCursor: nextCursor,
+			PrevCursor: prevCursor,
+			Data:       songs,
+		})
+		return
+	}
+
+	log.Printf("ListSongs: Fetching songs with filter %+v, sort %v, page %d, limit %d", filter, sort, page, limit)
+	songs, totalSongs, err := h.MusicService.ListSongs(filter, sort, limit, offset)
 	if err != nil {
 		log.Println("ListSongs: Failed to list songs")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list songs"})
@@ -251,17 +473,147 @@ func (h *MusicHandler) ListSongs(c *gin.Context) {
 	})
 }
 
+// songFilter reads the filter query parameters shared by ListSongs and
+// ExportSongs. Its errors are meant for the client.
+func songFilter(c *gin.Context) (models.SongFilter, error) {
+	filter := models.SongFilter{
+		Group: strings.TrimSpace(c.Query("group")),
+		Title: strings.TrimSpace(c.Query("title")),
+	}
+	if artistParam := c.Query("artistId"); artistParam != "" {
+		artistID, err := strconv.Atoi(artistParam)
+		if err != nil || artistID <= 0 {
+			return filter, errors.New("Invalid artist ID")
+		}
+		id := uint(artistID)
+		filter.ArtistID = &id
+	}
+
+	var err error
+	if filter.ReleasedFrom, err = dateQuery(c, "releasedFrom"); err != nil {
+		return filter, err
+	}
+	if filter.ReleasedTo, err = dateQuery(c, "releasedTo"); err != nil {
+		return filter, err
+	}
+	if filter.ReleasedFrom != nil && filter.ReleasedTo != nil && filter.ReleasedFrom.After(*filter.ReleasedTo) {
+		return filter, errors.New("'releasedFrom' must not be after 'releasedTo'")
+	}
+	if filter.HasLyrics, err = boolQuery(c, "hasLyrics"); err != nil {
+		return filter, err
+	}
+	if filter.HasLink, err = boolQuery(c, "hasLink"); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// dateQuery reads an optional 2006-01-02 query parameter.
+func dateQuery(c *gin.Context, param string) (*time.Time, error) {
+	value := c.Query(param)
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("'%s' must look like 2006-01-02", param)
+	}
+	return &date, nil
+}
+
+// boolQuery reads an optional true/false query parameter.
+func boolQuery(c *gin.Context, param string) (*bool, error) {
+	value := c.Query(param)
+	if value == "" {
+		return nil, nil
+	}
+	flag, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("'%s' must be true or false", param)
+	}
+	return &flag, nil
+}
+
+// SearchSongs godoc
+// @Summary Search songs
+// @Description Full-text search over titles, groups and lyrics with prefix matching, ranked by relevance. Each result carries the best matching verse as escaped HTML, with matched words wrapped in <mark> tags.
+// @Tags Songs
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param q query string true "Search query"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Number of results per page (default: 10)"
+// @Success 200 {object} types.PaginatedSearchResponse "Ranked search results"
+// @Failure 400 {object} types.ErrorResponse "Missing or empty search query"
+// @Failure 500 {object} types.ErrorResponse "Failed to search songs"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Router /search [get]
+func (h *MusicHandler) SearchSongs(c *gin.Context) {
+	log.Println("SearchSongs: Received request to search songs")
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		log.Println("SearchSongs: Missing required query parameter 'q'")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Search query 'q' is required"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	log.Printf("SearchSongs: Searching for '%s', page %d, limit %d", query, page, limit)
+	results, totalResults, err := h.MusicService.SearchSongs(query, limit, (page-1)*limit)
+	if err != nil {
+		if errors.Is(err, services.ErrEmptySearchQuery) {
+			log.Println("SearchSongs: Query has no searchable terms")
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Search query has no searchable terms"})
+			return
+		}
+		log.Println("SearchSongs: Failed to search songs")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search songs"})
+		return
+	}
+
+	log.Println("SearchSongs: Search completed successfully")
+	c.JSON(http.StatusOK, types.PaginatedSearchResponse{
+		Query:        query,
+		Page:         page,
+		Limit:        limit,
+		TotalPages:   (totalResults + limit - 1) / limit,
+		TotalResults: totalResults,
+		Data:         results,
+	})
+}
+
 // GetLyrics godoc
 // @Summary Get lyrics of a song
-// @Description Retrieves the lyrics of a song in a paginated format
+// @Description Retrieves the lyrics of a song in a paginated format. With format=lrc or format=json it returns the synchronized lyrics instead, as an LRC file or as a types.SyncedLyricsResponse with the start time of every line in milliseconds. If-None-Match and If-Modified-Since return 304 while the song is unchanged.
 // @Tags Songs
+// @Produce json,plain
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "The ID of the song"
+// @Param format query string false "Return the synchronized lyrics in this format" Enums(lrc, json)
 // @Param page query int false "Page number (default: 1)"
 // @Param limit query int false "Number of verses per page (default: 5)"
+// @Param If-None-Match header string false "ETag of the copy the client has"
+// @Param If-Modified-Since header string false "Last-Modified of the copy the client has"
 // @Success 200 {object} types.PaginatedVersesResponse "Paginated lyrics of the song"
-// @Failure 400 {object} types.ErrorResponse "Invalid song ID or pagination parameters"
-// @Failure 404 {object} types.ErrorResponse "Song not found"
+// @Header 200 {string} ETag "The version of the song"
+// @Header 200 {string} Last-Modified "When the song was last changed"
+// @Header 200 {string} Cache-Control "The caching policy of the route"
+// @Success 304 {string} string "The client's copy is current"
+// @Failure 400 {object} types.ErrorResponse "Invalid song ID, format or pagination parameters"
+// @Failure 404 {object} types.ErrorResponse "Song not found, or it has no synchronized lyrics"
 // @Failure 500 {object} types.ErrorResponse "Failed to fetch the lyrics"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
 // @Router /lyrics/{id} [get]
 func (h *MusicHandler) GetLyrics(c *gin.Context) {
 	log.Println("GetLyrics: Received request to fetch lyrics")
@@ -273,6 +625,13 @@ func (h *MusicHandler) GetLyrics(c *gin.Context) {
 		return
 	}
 
+	format := c.Query("format")
+	if format != "" && format != lyricsFormatLRC && format != lyricsFormatJSON {
+		log.Println("GetLyrics: Unknown format")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown format: use lrc or json"})
+		return
+	}
+
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "5"))
 
@@ -296,6 +655,22 @@ func (h *MusicHandler) GetLyrics(c *gin.Context) {
 		return
 	}
 
+	if format != "" && len(song.SyncedLyrics) == 0 {
+		log.Println("GetLyrics: Song has no synchronized lyrics")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Song has no synchronized lyrics"})
+		return
+	}
+
+	if h.checkNotModified(c, song) {
+		log.Println("GetLyrics: Lyrics not modified")
+		return
+	}
+
+	if format != "" {
+		writeSyncedLyrics(c, song, format)
+		return
+	}
+
 	verses := splitLyricsIntoVerses(song.Text)
 	totalVerses := len(verses)
 	start := (page - 1) * limit
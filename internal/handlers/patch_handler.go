@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srmbackisdeveloper/test-music-info/internal/middleware"
+	"github.com/srmbackisdeveloper/test-music-info/internal/services"
+	"gorm.io/gorm"
+)
+
+// maxPatchSize caps PATCH bodies; lyrics are the largest field.
+const maxPatchSize = 1 << 20
+
+// Patch media types.
+const (
+	mergePatchContentType = "application/merge-patch+json"
+	jsonPatchContentType  = "application/json-patch+json"
+)
+
+// PatchSong godoc
+// @Summary Partially update a song
+// @Description Updates only the fields named in the patch; the others keep their stored value. The patch applies to the document {"group", "title", "releaseDate" (2006-01-02, or "" when unknown), "text", "link"}. Send a JSON Merge Patch (RFC 7396) as application/merge-patch+json, e.g. {"link": "https://..."}, or a JSON Patch (RFC 6902) as application/json-patch+json, e.g. [{"op": "replace", "path": "/text", "value": "..."}]. With application/json, an array is read as JSON Patch and an object as merge patch. Removed fields are cleared; group and title cannot be. The patch is applied atomically and recorded as a revision. Send the ETag of the song as If-Match to apply it only if nobody changed the song since it was read.
+// @Tags Songs
+// @Accept json,application/merge-patch+json,application/json-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "The ID of the song to update"
+// @Param If-Match header string false "ETag of the song the patch is based on; required when the server enforces it"
+// @Param patch body object true "JSON Merge Patch object or JSON Patch array"
+// @Success 200 {object} models.Music "The updated song"
+// @Header 200 {string} ETag "The new version of the song"
+// @Failure 400 {object} types.ErrorResponse "Invalid song ID or malformed patch"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Insufficient permissions"
+// @Failure 404 {object} types.ErrorResponse "Song not found"
+// @Failure 409 {object} types.ErrorResponse "A JSON Patch test failed, or another song has the patched group and title"
+// @Failure 412 {object} types.ErrorResponse "The song was changed since the ETag in If-Match"
+// @Failure 413 {object} types.ErrorResponse "Patch larger than 1 MiB"
+// @Failure 415 {object} types.ErrorResponse "Unsupported patch content type"
+// @Failure 422 {object} types.ErrorResponse "The patched song is invalid"
+// @Failure 428 {object} types.ErrorResponse "If-Match is required"
+// @Failure 500 {object} types.ErrorResponse "Failed to update the song"
+// @Router /music/{id} [patch]
+func (h *MusicHandler) PatchSong(c *gin.Context) {
+	log.Println("PatchSong: Received request to patch a song")
+	songID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || songID <= 0 {
+		log.Println("PatchSong: Invalid song ID")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid song ID"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxPatchSize)
+	patch, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			log.Println("PatchSong: Patch too large")
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Patch must not exceed 1 MiB"})
+			return
+		}
+		log.Println("PatchSong: Failed to read the patch")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read the patch"})
+		return
+	}
+
+	var format string
+	switch c.ContentType() {
+	case mergePatchContentType:
+		format = services.PatchFormatMerge
+	case jsonPatchContentType:
+		format = services.PatchFormatJSON
+	case "application/json":
+		format = services.PatchFormatMerge
+		if bytes.HasPrefix(bytes.TrimSpace(patch), []byte("[")) {
+			format = services.PatchFormatJSON
+		}
+	default:
+		log.Printf("PatchSong: Unsupported content type %q", c.ContentType())
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Content type must be " + mergePatchContentType + " or " + jsonPatchContentType})
+		return
+	}
+
+	current, err := h.MusicService.GetSongByID(uint(songID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Println("PatchSong: Song not found")
+			c.JSON(http.StatusNotFound, gin.H{"error": "Song not found"})
+			return
+		}
+		log.Println("PatchSong: Failed to fetch song")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch song"})
+		return
+	}
+	if !h.checkIfMatch(c, current) {
+		log.Printf("PatchSong: If-Match does not hold for song %d at version %d", songID, current.Version)
+		return
+	}
+
+	log.Printf("PatchSong: Applying %s patch to song %d", format, songID)
+	song, err := h.MusicService.PatchSong(uint(songID), current.Version, format, patch, middleware.CurrentSubject(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			log.Println("PatchSong: Song not found")
+			c.JSON(http.StatusNotFound, gin.H{"error": "Song not found"})
+		case errors.Is(err, services.ErrVersionConflict):
+			log.Printf("PatchSong: Song %d changed while it was being patched", songID)
+			writePreconditionFailed(c)
+		case errors.Is(err, services.ErrInvalidPatch):
+			log.Printf("PatchSong: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrInvalidSong):
+			log.Printf("PatchSong: %v", err)
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrPatchTestFailed):
+			log.Printf("PatchSong: %v", err)
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrSongExists):
+			log.Printf("PatchSong: %v", err)
+			writeSongConflict(c, err)
+		default:
+			log.Println("PatchSong: Failed to update song")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update song"})
+		}
+		return
+	}
+
+	log.Println("PatchSong: Song patched successfully")
+	setSongETag(c, song)
+	c.JSON(http.StatusOK, gin.H{"message": "Song updated successfully", "song": song})
+}
@@ -0,0 +1,337 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srmbackisdeveloper/test-music-info/internal/middleware"
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/services"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"gorm.io/gorm"
+)
+
+type PlaylistHandler struct {
+	PlaylistService services.PlaylistServicer
+}
+
+func NewPlaylistHandler(playlistService services.PlaylistServicer) *PlaylistHandler {
+	return &PlaylistHandler{PlaylistService: playlistService}
+}
+
+// AddPlaylist godoc
+// @Summary Create a playlist
+// @Description Creates an empty playlist owned by the caller. Visibility defaults to private.
+// @Tags Playlists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param playlist body types.AddPlaylistRequest true "Playlist to create"
+// @Success 201 {object} models.Playlist "The created playlist"
+// @Failure 400 {object} types.ErrorResponse "Invalid request payload"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 500 {object} types.ErrorResponse "Failed to create the playlist"
+// @Router /playlists [post]
+func (h *PlaylistHandler) AddPlaylist(c *gin.Context) {
+	log.Println("AddPlaylist: Received request to create a playlist")
+	var req types.AddPlaylistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("AddPlaylist: Invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: 'name' is required, 'visibility' must be public or private"})
+		return
+	}
+
+	claims, _ := middleware.CurrentClaims(c)
+	playlist := &models.Playlist{Owner: claims.Subject, Name: req.Name, Visibility: req.Visibility}
+	log.Printf("AddPlaylist: Creating playlist '%s' for '%s'", playlist.Name, playlist.Owner)
+	if err := h.PlaylistService.AddPlaylist(playlist); err != nil {
+		log.Println("AddPlaylist: Failed to create playlist")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create playlist"})
+		return
+	}
+
+	log.Println("AddPlaylist: Playlist created successfully")
+	c.JSON(http.StatusCreated, gin.H{"message": "Playlist created successfully", "playlist": playlist})
+}
+
+// GetPlaylist godoc
+// @Summary Retrieve a playlist
+// @Description Fetches a playlist with its ordered entries. Private playlists are only visible to their owner and admins.
+// @Tags Playlists
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "The ID of the playlist"
+// @Success 200 {object} models.Playlist "The requested playlist"
+// @Failure 400 {object} types.ErrorResponse "Invalid playlist ID"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 404 {object} types.ErrorResponse "Playlist not found"
+// @Failure 500 {object} types.ErrorResponse "Failed to fetch the playlist"
+// @Router /playlists/{id} [get]
+func (h *PlaylistHandler) GetPlaylist(c *gin.Context) {
+	log.Println("GetPlaylist: Received request to fetch a playlist")
+	playlistID, ok := parsePlaylistID(c, "GetPlaylist")
+	if !ok {
+		return
+	}
+
+	playlist, err := h.PlaylistService.GetPlaylistByID(playlistID)
+	if err != nil {
+		respondPlaylistError(c, "GetPlaylist", err)
+		return
+	}
+	if playlist.Visibility != models.VisibilityPublic && !canManagePlaylist(c, playlist) {
+		log.Println("GetPlaylist: Private playlist of another user")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Playlist not found"})
+		return
+	}
+
+	log.Println("GetPlaylist: Playlist fetched successfully")
+	c.JSON(http.StatusOK, playlist)
+}
+
+// ListPlaylists godoc
+// @Summary List playlists
+// @Description Retrieves a paginated list of public playlists, optionally of one owner. Callers listing their own playlists (and admins) also see private ones.
+// @Tags Playlists
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param owner query string false "Only playlists of this owner"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Number of playlists per page (default: 10)"
+// @Success 200 {object} types.PaginatedPlaylistsResponse "Paginated list of playlists"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 500 {object} types.ErrorResponse "Failed to fetch the list of playlists"
+// @Router /playlists [get]
+func (h *PlaylistHandler) ListPlaylists(c *gin.Context) {
+	log.Println("ListPlaylists: Received request to list playlists")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	owner := c.Query("owner")
+	claims, _ := middleware.CurrentClaims(c)
+	includePrivate := owner != "" && (owner == claims.Subject || claims.Role == models.RoleAdmin)
+	playlists, totalPlaylists, err := h.PlaylistService.ListPlaylists(owner, includePrivate, limit, (page-1)*limit)
+	if err != nil {
+		log.Println("ListPlaylists: Failed to list playlists")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list playlists"})
+		return
+	}
+
+	log.Println("ListPlaylists: Playlists listed successfully")
+	c.JSON(http.StatusOK, types.PaginatedPlaylistsResponse{
+		Page:           page,
+		Limit:          limit,
+		TotalPages:     (totalPlaylists + limit - 1) / limit,
+		TotalPlaylists: totalPlaylists,
+		Data:           playlists,
+	})
+}
+
+// AddEntry godoc
+// @Summary Add a song to a playlist
+// @Description Inserts a song at the given 1-based position, or appends it when position is omitted
+// @Tags Playlists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "The ID of the playlist"
+// @Param entry body types.AddPlaylistEntryRequest true "Song and optional position"
+// @Success 201 {object} models.Playlist "The updated playlist"
+// @Failure 400 {object} types.ErrorResponse "Invalid playlist ID, payload, song or position"
+// @Failure 404 {object} types.ErrorResponse "Playlist not found"
+// @Failure 500 {object} types.ErrorResponse "Failed to add the entry"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Not the playlist owner"
+// @Router /playlists/{id}/entries [post]
+func (h *PlaylistHandler) AddEntry(c *gin.Context) {
+	log.Println("AddEntry: Received request to add a playlist entry")
+	playlistID, ok := parsePlaylistID(c, "AddEntry")
+	if !ok {
+		return
+	}
+
+	if !h.authorizePlaylistWrite(c, playlistID, "AddEntry") {
+		return
+	}
+
+	var req types.AddPlaylistEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("AddEntry: Invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: 'songId' is required"})
+		return
+	}
+
+	log.Printf("AddEntry: Adding song %d to playlist %d", req.SongID, playlistID)
+	playlist, err := h.PlaylistService.AddEntry(playlistID, req.SongID, req.Position)
+	if err != nil {
+		respondPlaylistError(c, "AddEntry", err)
+		return
+	}
+
+	log.Println("AddEntry: Entry added successfully")
+	c.JSON(http.StatusCreated, gin.H{"message": "Entry added successfully", "playlist": playlist})
+}
+
+// MoveEntry godoc
+// @Summary Move a playlist entry
+// @Description Moves an entry to a new 1-based position
+// @Tags Playlists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "The ID of the playlist"
+// @Param entryId path int true "The ID of the entry"
+// @Param position body types.MovePlaylistEntryRequest true "New position"
+// @Success 200 {object} models.Playlist "The updated playlist"
+// @Failure 400 {object} types.ErrorResponse "Invalid IDs, payload or position"
+// @Failure 404 {object} types.ErrorResponse "Playlist or entry not found"
+// @Failure 500 {object} types.ErrorResponse "Failed to move the entry"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Not the playlist owner"
+// @Router /playlists/{id}/entries/{entryId} [put]
+func (h *PlaylistHandler) MoveEntry(c *gin.Context) {
+	log.Println("MoveEntry: Received request to move a playlist entry")
+	playlistID, entryID, ok := parseEntryIDs(c, "MoveEntry")
+	if !ok {
+		return
+	}
+
+	if !h.authorizePlaylistWrite(c, playlistID, "MoveEntry") {
+		return
+	}
+
+	var req types.MovePlaylistEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("MoveEntry: Invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: 'position' must be at least 1"})
+		return
+	}
+
+	log.Printf("MoveEntry: Moving entry %d of playlist %d to position %d", entryID, playlistID, req.Position)
+	playlist, err := h.PlaylistService.MoveEntry(playlistID, entryID, req.Position)
+	if err != nil {
+		respondPlaylistError(c, "MoveEntry", err)
+		return
+	}
+
+	log.Println("MoveEntry: Entry moved successfully")
+	c.JSON(http.StatusOK, gin.H{"message": "Entry moved successfully", "playlist": playlist})
+}
+
+// RemoveEntry godoc
+// @Summary Remove a playlist entry
+// @Description Removes an entry from a playlist; the following entries move up
+// @Tags Playlists
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "The ID of the playlist"
+// @Param entryId path int true "The ID of the entry"
+// @Success 200 {object} models.Playlist "The updated playlist"
+// @Failure 400 {object} types.ErrorResponse "Invalid IDs"
+// @Failure 404 {object} types.ErrorResponse "Playlist or entry not found"
+// @Failure 500 {object} types.ErrorResponse "Failed to remove the entry"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Not the playlist owner"
+// @Router /playlists/{id}/entries/{entryId} [delete]
+func (h *PlaylistHandler) RemoveEntry(c *gin.Context) {
+	log.Println("RemoveEntry: Received request to remove a playlist entry")
+	playlistID, entryID, ok := parseEntryIDs(c, "RemoveEntry")
+	if !ok {
+		return
+	}
+
+	if !h.authorizePlaylistWrite(c, playlistID, "RemoveEntry") {
+		return
+	}
+
+	log.Printf("RemoveEntry: Removing entry %d from playlist %d", entryID, playlistID)
+	playlist, err := h.PlaylistService.RemoveEntry(playlistID, entryID)
+	if err != nil {
+		respondPlaylistError(c, "RemoveEntry", err)
+		return
+	}
+
+	log.Println("RemoveEntry: Entry removed successfully")
+	c.JSON(http.StatusOK, gin.H{"message": "Entry removed successfully", "playlist": playlist})
+}
+
+// authorizePlaylistWrite lets only the playlist owner and admins change it and
+// writes the error response itself.
+func (h *PlaylistHandler) authorizePlaylistWrite(c *gin.Context, playlistID uint, op string) bool {
+	playlist, err := h.PlaylistService.GetPlaylistByID(playlistID)
+	if err != nil {
+		respondPlaylistError(c, op, err)
+		return false
+	}
+	if !canManagePlaylist(c, playlist) {
+		log.Printf("%s: Caller does not own playlist %d", op, playlistID)
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the playlist owner can change it"})
+		return false
+	}
+	return true
+}
+
+func canManagePlaylist(c *gin.Context, playlist *models.Playlist) bool {
+	claims, ok := middleware.CurrentClaims(c)
+	return ok && (claims.Subject == playlist.Owner || claims.Role == models.RoleAdmin)
+}
+
+func parsePlaylistID(c *gin.Context, op string) (uint, bool) {
+	playlistID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || playlistID <= 0 {
+		log.Printf("%s: Invalid playlist ID", op)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid playlist ID"})
+		return 0, false
+	}
+	return uint(playlistID), true
+}
+
+func parseEntryIDs(c *gin.Context, op string) (uint, uint, bool) {
+	playlistID, ok := parsePlaylistID(c, op)
+	if !ok {
+		return 0, 0, false
+	}
+
+	entryID, err := strconv.Atoi(c.Param("entryId"))
+	if err != nil || entryID <= 0 {
+		log.Printf("%s: Invalid entry ID", op)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entry ID"})
+		return 0, 0, false
+	}
+	return playlistID, uint(entryID), true
+}
+
+func respondPlaylistError(c *gin.Context, op string, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		log.Printf("%s: Playlist not found", op)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Playlist not found"})
+	case errors.Is(err, services.ErrPlaylistEntryNotFound):
+		log.Printf("%s: Entry not found", op)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Entry not found"})
+	case errors.Is(err, services.ErrSongNotFound):
+		log.Printf("%s: Song not found", op)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Song not found"})
+	case errors.Is(err, services.ErrInvalidPosition):
+		log.Printf("%s: %v", op, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Printf("%s: Playlist operation failed: %v", op, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update playlist"})
+	}
+}
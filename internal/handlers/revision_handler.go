@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srmbackisdeveloper/test-music-info/internal/middleware"
+	"github.com/srmbackisdeveloper/test-music-info/internal/services"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"gorm.io/gorm"
+)
+
+// ListRevisions godoc
+// @Summary List song revisions
+// @Description Retrieves a paginated list of the changes made to a song, newest first
+// @Tags Revisions
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "The ID of the song"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Number of revisions per page (default: 10)"
+// @Success 200 {object} types.PaginatedRevisionsResponse "Paginated list of revisions"
+// @Failure 400 {object} types.ErrorResponse "Invalid song ID"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Insufficient permissions"
+// @Failure 404 {object} types.ErrorResponse "Song not found"
+// @Failure 500 {object} types.ErrorResponse "Failed to fetch revisions"
+// @Router /music/{id}/revisions [get]
+func (h *MusicHandler) ListRevisions(c *gin.Context) {
+	log.Println("ListRevisions: Received request to list song revisions")
+	songID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || songID <= 0 {
+		log.Println("ListRevisions: Invalid song ID")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid song ID"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	revisions, totalRevisions, err := h.MusicService.ListRevisions(uint(songID), limit, (page-1)*limit)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Println("ListRevisions: Song not found")
+			c.JSON(http.StatusNotFound, gin.H{"error": "Song not found"})
+			return
+		}
+		log.Println("ListRevisions: Failed to list revisions")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list revisions"})
+		return
+	}
+
+	log.Println("ListRevisions: Revisions listed successfully")
+	c.JSON(http.StatusOK, types.PaginatedRevisionsResponse{
+		Page:           page,
+		Limit:          limit,
+		TotalPages:     (totalRevisions + limit - 1) / limit,
+		TotalRevisions: totalRevisions,
+		Data:           revisions,
+	})
+}
+
+// DiffRevisions godoc
+// @Summary Compare two song revisions
+// @Description Lists the fields that differ between two revisions of a song, with "from" as the before side
+// @Tags Revisions
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "The ID of the song"
+// @Param from query int true "The earlier revision"
+// @Param to query int true "The later revision"
+// @Success 200 {object} types.RevisionDiffResponse "Changed fields"
+// @Failure 400 {object} types.ErrorResponse "Invalid song ID or revision numbers"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Insufficient permissions"
+// @Failure 404 {object} types.ErrorResponse "Song or revision not found"
+// @Failure 500 {object} types.ErrorResponse "Failed to compare revisions"
+// @Router /music/{id}/revisions/diff [get]
+func (h *MusicHandler) DiffRevisions(c *gin.Context) {
+	log.Println("DiffRevisions: Received request to compare song revisions")
+	songID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || songID <= 0 {
+		log.Println("DiffRevisions: Invalid song ID")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid song ID"})
+		return
+	}
+
+	from, fromErr := strconv.Atoi(c.Query("from"))
+	to, toErr := strconv.Atoi(c.Query("to"))
+	if fromErr != nil || toErr != nil || from < 1 || to < 1 {
+		log.Println("DiffRevisions: Invalid revision numbers")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "'from' and 'to' must be revision numbers"})
+		return
+	}
+
+	changes, err := h.MusicService.DiffRevisions(uint(songID), from, to)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Println("DiffRevisions: Song or revision not found")
+			c.JSON(http.StatusNotFound, gin.H{"error": "Song or revision not found"})
+			return
+		}
+		log.Println("DiffRevisions: Failed to compare revisions")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compare revisions"})
+		return
+	}
+
+	log.Printf("DiffRevisions: %d fields differ between revisions %d and %d", len(changes), from, to)
+	c.JSON(http.StatusOK, types.RevisionDiffResponse{SongID: uint(songID), From: from, To: to, Changes: changes})
+}
+
+// RestoreRevision godoc
+// @Summary Roll a song back to a revision
+// @Description Restores the fields a song had at the given revision. The rollback is recorded as a new revision. Send the ETag of the song as If-Match to roll it back only if nobody changed it since it was read; the response carries the new ETag.
+// @Tags Revisions
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "The ID of the song"
+// @Param rev path int true "The revision to restore"
+// @Param If-Match header string false "ETag of the song the rollback is based on; required when the server enforces it"
+// @Success 200 {object} models.Music "The restored song"
+// @Failure 400 {object} types.ErrorResponse "Invalid song ID or revision"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Insufficient permissions"
+// @Failure 404 {object} types.ErrorResponse "Song or revision not found"
+// @Failure 409 {object} types.SongConflictResponse "Another song has the group and title of the revision"
+// @Failure 412 {object} types.ErrorResponse "The song was changed since the ETag in If-Match"
+// @Failure 428 {object} types.ErrorResponse "If-Match is required"
+// @Failure 500 {object} types.ErrorResponse "Failed to restore the revision"
+// @Router /music/{id}/revisions/{rev}/restore [post]
+func (h *MusicHandler) RestoreRevision(c *gin.Context) {
+	log.Println("RestoreRevision: Received request to roll back a song")
+	songID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || songID <= 0 {
+		log.Println("RestoreRevision: Invalid song ID")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid song ID"})
+		return
+	}
+	revision, err := strconv.Atoi(c.Param("rev"))
+	if err != nil || revision <= 0 {
+		log.Println("RestoreRevision: Invalid revision")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision"})
+		return
+	}
+
+	current, err := h.MusicService.GetSongByID(uint(songID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Println("RestoreRevision: Song not found")
+			c.JSON(http.StatusNotFound, gin.H{"error": "Song or revision not found"})
+			return
+		}
+		log.Println("RestoreRevision: Failed to fetch song")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch song"})
+		return
+	}
+	if !h.checkIfMatch(c, current) {
+		log.Printf("RestoreRevision: If-Match does not hold for song %d at version %d", songID, current.Version)
+		return
+	}
+
+	song, err := h.MusicService.RestoreRevision(uint(songID), revision, current.Version, middleware.CurrentSubject(c))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Println("RestoreRevision: Song or revision not found")
+			c.JSON(http.StatusNotFound, gin.H{"error": "Song or revision not found"})
+			return
+		}
+		if errors.Is(err, services.ErrVersionConflict) {
+			log.Printf("RestoreRevision: Song %d changed while it was being restored", songID)
+			writePreconditionFailed(c)
+			return
+		}
+		if errors.Is(err, services.ErrSongExists) {
+			log.Printf("RestoreRevision: %v", err)
+			writeSongConflict(c, err)
+			return
+		}
+		log.Println("RestoreRevision: Failed to restore revision")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore revision"})
+		return
+	}
+
+	log.Printf("RestoreRevision: Song %d restored to revision %d", songID, revision)
+	setSongETag(c, song)
+	c.JSON(http.StatusOK, gin.H{"message": "Song restored to revision " + strconv.Itoa(revision), "song": song})
+}
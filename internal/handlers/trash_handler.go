@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srmbackisdeveloper/test-music-info/internal/middleware"
+	"github.com/srmbackisdeveloper/test-music-info/internal/services"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"gorm.io/gorm"
+)
+
+// ListTrash godoc
+// @Summary List deleted songs
+// @Description Retrieves a paginated list of songs in the trash, most recently deleted first
+// @Tags Trash
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Number of songs per page (default: 10)"
+// @Success 200 {object} types.PaginatedSongsResponse "Paginated list of deleted songs"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Insufficient permissions"
+// @Failure 500 {object} types.ErrorResponse "Failed to fetch the trash"
+// @Router /music/trash [get]
+func (h *MusicHandler) ListTrash(c *gin.Context) {
+	log.Println("ListTrash: Received request to list deleted songs")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	songs, totalSongs, err := h.MusicService.ListTrash(limit, (page-1)*limit)
+	if err != nil {
+		log.Println("ListTrash: Failed to list deleted songs")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list deleted songs"})
+		return
+	}
+
+	log.Println("ListTrash: Deleted songs listed successfully")
+	c.JSON(http.StatusOK, types.PaginatedSongsResponse{
+		Page:       page,
+		Limit:      limit,
+		TotalPages: (totalSongs + limit - 1) / limit,
+		TotalSongs: totalSongs,
+		Data:       songs,
+	})
+}
+
+// RestoreSong godoc
+// @Summary Restore a deleted song
+// @Description Moves a song out of the trash
+// @Tags Trash
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "The ID of the deleted song"
+// @Success 200 {object} models.Music "The restored song"
+// @Failure 400 {object} types.ErrorResponse "Invalid song ID"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Insufficient permissions"
+// @Failure 404 {object} types.ErrorResponse "Song not in the trash"
+// @Failure 409 {object} types.SongConflictResponse "Another song has taken its group and title"
+// @Failure 500 {object} types.ErrorResponse "Failed to restore the song"
+// @Router /music/{id}/restore [post]
+func (h *MusicHandler) RestoreSong(c *gin.Context) {
+	log.Println("RestoreSong: Received request to restore a song")
+	songID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || songID <= 0 {
+		log.Println("RestoreSong: Invalid song ID")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid song ID"})
+		return
+	}
+
+	song, err := h.MusicService.RestoreSong(uint(songID), middleware.CurrentSubject(c))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Println("RestoreSong: Song not in the trash")
+			c.JSON(http.StatusNotFound, gin.H{"error": "Song not found in the trash"})
+			return
+		}
+		if errors.Is(err, services.ErrSongExists) {
+			log.Printf("RestoreSong: %v", err)
+			writeSongConflict(c, err)
+			return
+		}
+		log.Println("RestoreSong: Failed to restore song")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore song"})
+		return
+	}
+
+	log.Printf("RestoreSong: Song %d restored successfully", songID)
+	setSongETag(c, song)
+	c.JSON(http.StatusOK, gin.H{"message": "Song restored successfully", "song": song})
+}
+
+// PurgeTrash godoc
+// @Summary Empty the trash
+// @Description Permanently deletes songs that have been in the trash for longer than the configured retention period (admin only)
+// @Tags Trash
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Success 200 {object} types.PurgeTrashResponse "Number of songs permanently deleted"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Insufficient permissions"
+// @Failure 500 {object} types.ErrorResponse "Failed to purge the trash"
+// @Router /music/trash [delete]
+func (h *MusicHandler) PurgeTrash(c *gin.Context) {
+	log.Println("PurgeTrash: Received request to purge the trash")
+	purged, err := h.MusicService.PurgeTrash()
+	if err != nil {
+		log.Println("PurgeTrash: Failed to purge the trash")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to purge the trash"})
+		return
+	}
+
+	log.Printf("PurgeTrash: %d songs permanently deleted", purged)
+	c.JSON(http.StatusOK, types.PurgeTrashResponse{Message: "Trash purged successfully", Purged: purged})
+}
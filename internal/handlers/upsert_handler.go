@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srmbackisdeveloper/test-music-info/internal/middleware"
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/services"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"gorm.io/gorm"
+)
+
+// UpsertSong godoc
+// @Summary Create or replace a song by group and title
+// @Description Stores the release date, text and link of the song identified by the group and song query parameters. The song is looked up the way GET /info does; an existing one keeps its stored group and title and has its fields replaced, otherwise a new song is created without calling the song details API. Changed fields are marked as set manually. Send the ETag of an existing song as If-Match to replace it only if nobody changed it since it was read; the response carries the new ETag.
+// @Tags Songs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param group query string true "The group of the song"
+// @Param song query string true "The title of the song"
+// @Param If-Match header string false "ETag of the existing song; required when the server enforces it"
+// @Param fields body types.UpsertSongRequest true "Fields to store"
+// @Success 200 {object} models.Music "The updated song"
+// @Success 201 {object} models.Music "The created song"
+// @Failure 400 {object} types.ErrorResponse "Missing group or song, or invalid payload"
+// @Failure 401 {object} types.ErrorResponse "Authentication required"
+// @Failure 403 {object} types.ErrorResponse "Insufficient permissions"
+// @Failure 409 {object} types.SongConflictResponse "The song was created concurrently"
+// @Failure 412 {object} types.ErrorResponse "The song was changed since the ETag in If-Match"
+// @Failure 428 {object} types.ErrorResponse "If-Match is required"
+// @Failure 500 {object} types.ErrorResponse "Failed to save the song"
+// @Router /music [put]
+func (h *MusicHandler) UpsertSong(c *gin.Context) {
+	log.Println("UpsertSong: Received request to upsert a song")
+	group := strings.TrimSpace(c.Query("group"))
+	title := strings.TrimSpace(c.Query("song"))
+	if group == "" || title == "" {
+		log.Println("UpsertSong: Missing required query parameters 'group' or 'song'")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Group and song are required"})
+		return
+	}
+
+	var req types.UpsertSongRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("UpsertSong: Invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: 'releaseDate' must look like 2006-01-02 and 'link' must be a URL"})
+		return
+	}
+
+	fields := &models.Music{Text: req.Text, Link: req.Link}
+	if req.ReleaseDate != "" {
+		fields.ReleaseDate, _ = time.Parse("2006-01-02", req.ReleaseDate)
+	}
+
+	var version uint
+	existing, err := h.MusicService.GetSong(group, title)
+	switch {
+	case err == nil:
+		if !h.checkIfMatch(c, existing) {
+			log.Printf("UpsertSong: If-Match does not hold for song %d at version %d", existing.ID, existing.Version)
+			return
+		}
+		version = existing.Version
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		// If-Match only holds for a song that exists
+		if c.GetHeader("If-Match") != "" {
+			log.Println("UpsertSong: If-Match sent for a song that does not exist")
+			writePreconditionFailed(c)
+			return
+		}
+	default:
+		log.Println("UpsertSong: Failed to fetch song")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch song"})
+		return
+	}
+
+	log.Printf("UpsertSong: Saving song with group '%s' and title '%s'", group, title)
+	song, created, err := h.MusicService.UpsertSong(group, title, version, fields, middleware.CurrentSubject(c))
+	if err != nil {
+		if errors.Is(err, services.ErrVersionConflict) {
+			log.Printf("UpsertSong: Song '%s' - '%s' changed while it was being saved", group, title)
+			writePreconditionFailed(c)
+			return
+		}
+		if errors.Is(err, services.ErrSongExists) {
+			log.Printf("UpsertSong: %v", err)
+			writeSongConflict(c, err)
+			return
+		}
+		log.Println("UpsertSong: Failed to save song")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save song"})
+		return
+	}
+
+	if created {
+		log.Printf("UpsertSong: Song %d created", song.ID)
+		setSongETag(c, song)
+		c.JSON(http.StatusCreated, gin.H{"message": "Song added successfully", "song": song})
+		return
+	}
+	log.Printf("UpsertSong: Song %d updated", song.ID)
+	setSongETag(c, song)
+	c.JSON(http.StatusOK, gin.H{"message": "Song updated successfully", "song": song})
+}
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/services"
+)
+
+const claimsKey = "authClaims"
+
+// RequireAuth rejects requests without a valid "X-API-Key" header or
+// "Authorization: Bearer <jwt>" header and stores the caller's claims in the
+// context. An API key takes precedence when both are sent.
+func RequireAuth(authService services.AuthServicer, apiKeyService services.APIKeyServicer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if apiKey := c.GetHeader("X-API-Key"); apiKey != "" {
+			claims, err := apiKeyService.Authenticate(apiKey)
+			if err != nil {
+				log.Printf("RequireAuth: %v", err)
+				if errors.Is(err, services.ErrInvalidAPIKey) {
+					c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid, expired or revoked API key"})
+					return
+				}
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to check API key"})
+				return
+			}
+
+			c.Set(claimsKey, claims)
+			c.Next()
+			return
+		}
+
+		header := c.GetHeader("Authorization")
+		token, found := strings.CutPrefix(header, "Bearer ")
+		if !found || token == "" {
+			log.Println("RequireAuth: Missing bearer token")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+			return
+		}
+
+		claims, err := authService.ParseToken(token)
+		if err != nil {
+			log.Printf("RequireAuth: %v", err)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			return
+		}
+
+		c.Set(claimsKey, claims)
+		c.Next()
+	}
+}
+
+// RequireRole lets the request through when the caller's role is at least
+// minRole, or, for API keys, when the key has the matching scope.
+// It must run after RequireAuth.
+func RequireRole(minRole string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := CurrentClaims(c)
+		if !ok || !allowed(claims, minRole) {
+			log.Printf("RequireRole: Role '%s' required", minRole)
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			return
+		}
+		c.Next()
+	}
+}
+
+func allowed(claims *services.Claims, minRole string) bool {
+	if claims.IsAPIKey() {
+		return claims.Scopes.Has(models.ScopeForRole(minRole))
+	}
+	return models.RoleAtLeast(claims.Role, minRole)
+}
+
+// CurrentClaims returns the claims RequireAuth stored for this request.
+func CurrentClaims(c *gin.Context) (*services.Claims, bool) {
+	value, exists := c.Get(claimsKey)
+	if !exists {
+		return nil, false
+	}
+	claims, ok := value.(*services.Claims)
+	return claims, ok
+}
+
+// CurrentSubject returns the user name, or "apikey:<name>" for API keys, that
+// the request is authenticated as, or "" for unauthenticated requests.
+func CurrentSubject(c *gin.Context) string {
+	claims, ok := CurrentClaims(c)
+	if !ok {
+		return ""
+	}
+	return claims.Subject
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srmbackisdeveloper/test-music-info/internal/services"
+)
+
+// RateLimit rejects requests over the route's limit with 429. Clients are
+// identified by API key, then user, then IP, so it should run after
+// RequireAuth on authenticated routes. When the limiter's store fails the
+// request is let through.
+func RateLimit(limiter services.RateLimitServicer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.Request.Method + " " + c.FullPath()
+		decision, err := limiter.Allow(route, clientIdentity(c))
+		if err != nil {
+			log.Printf("RateLimit: Failed to check limit for %s: %v", route, err)
+			c.Next()
+			return
+		}
+		if decision == nil {
+			c.Next()
+			return
+		}
+
+		resetSeconds := strconv.Itoa(int(math.Ceil(decision.ResetAfter.Seconds())))
+		c.Header("X-RateLimit-Limit", strconv.Itoa(decision.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		c.Header("X-RateLimit-Reset", resetSeconds)
+
+		if !decision.Allowed {
+			log.Printf("RateLimit: Too many requests to %s", route)
+			c.Header("Retry-After", resetSeconds)
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests, retry in " + resetSeconds + " seconds"})
+			return
+		}
+		c.Next()
+	}
+}
+
+func clientIdentity(c *gin.Context) string {
+	if claims, ok := CurrentClaims(c); ok {
+		if claims.IsAPIKey() {
+			return fmt.Sprintf("apikey:%d", claims.APIKeyID)
+		}
+		return "user:" + claims.Subject
+	}
+	return "ip:" + c.ClientIP()
+}
@@ -0,0 +1,104 @@
+// Package migrations applies the versioned SQL schema embedded in the binary.
+//
+// Each migration is a pair of files in sql/ named
+// "<version>_<name>.up.sql" and "<version>_<name>.down.sql". Versions are
+// applied in ascending order, each in its own transaction, and recorded in
+// the schema_migrations table. A migration whose first line is
+// "-- migrate:no-transaction" runs outside a transaction, which is needed for
+// statements such as CREATE INDEX CONCURRENTLY.
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed sql/*.sql
+var embedded embed.FS
+
+const noTransactionDirective = "-- migrate:no-transaction"
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// UpInTransaction reports whether the up script may run inside a transaction.
+func (m Migration) UpInTransaction() bool {
+	return !strings.HasPrefix(strings.TrimSpace(m.Up), noTransactionDirective)
+}
+
+// DownInTransaction reports whether the down script may run inside a transaction.
+func (m Migration) DownInTransaction() bool {
+	return !strings.HasPrefix(strings.TrimSpace(m.Down), noTransactionDirective)
+}
+
+// All returns the migrations embedded in the binary.
+func All() ([]Migration, error) {
+	return Load(embedded, "sql")
+}
+
+// Load reads the migrations in dir, sorted by version. Every version must have
+// both an up and a down file.
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migration %q: name must look like 0001_name.up.sql", entry.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %q: invalid version", entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d: conflicting names %q and %q", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if strings.TrimSpace(migration.Up) == "" || strings.TrimSpace(migration.Down) == "" {
+			return nil, fmt.Errorf("migration %d_%s: needs non-empty up and down files", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
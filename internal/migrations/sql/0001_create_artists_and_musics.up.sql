@@ -0,0 +1,50 @@
+-- Baseline schema. Databases created by the old AutoMigrate startup already
+-- have a musics table with only id, group_name, title, release_date, text,
+-- link, created_at and updated_at; the ALTERs below add what it lacks.
+CREATE TABLE IF NOT EXISTS artists (
+    id              bigserial PRIMARY KEY,
+    name            text NOT NULL,
+    normalized_name text NOT NULL,
+    sort_name       text,
+    country         varchar(2),
+    formed_year     bigint,
+    biography       text,
+    created_at      timestamptz,
+    updated_at      timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_artists_normalized_name ON artists (normalized_name);
+
+CREATE TABLE IF NOT EXISTS musics (
+    id                  bigserial PRIMARY KEY,
+    group_name          text NOT NULL,
+    artist_id           bigint REFERENCES artists (id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    title               text NOT NULL,
+    release_date        date,
+    text                text,
+    link                text,
+    source_release_date text,
+    source_text         text,
+    source_link         text,
+    created_at          timestamptz,
+    updated_at          timestamptz
+);
+
+ALTER TABLE musics ADD COLUMN IF NOT EXISTS artist_id bigint;
+ALTER TABLE musics ADD COLUMN IF NOT EXISTS source_release_date text;
+ALTER TABLE musics ADD COLUMN IF NOT EXISTS source_text text;
+ALTER TABLE musics ADD COLUMN IF NOT EXISTS source_link text;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1
+        FROM pg_constraint c
+        JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = ANY (c.conkey)
+        WHERE c.conrelid = 'musics'::regclass AND c.contype = 'f' AND a.attname = 'artist_id'
+    ) THEN
+        ALTER TABLE musics ADD CONSTRAINT musics_artist_id_fkey
+            FOREIGN KEY (artist_id) REFERENCES artists (id) ON UPDATE CASCADE ON DELETE RESTRICT;
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_musics_artist_id ON musics (artist_id);
@@ -0,0 +1,8 @@
+-- Title matches weigh more than group matches, which weigh more than lyrics.
+ALTER TABLE musics ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(group_name, '')), 'B') ||
+        setweight(to_tsvector('simple', coalesce(text, '')), 'C')
+    ) STORED;
+CREATE INDEX IF NOT EXISTS idx_musics_search_vector ON musics USING GIN (search_vector);
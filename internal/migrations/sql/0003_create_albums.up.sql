@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS albums (
+    id           bigserial PRIMARY KEY,
+    title        text NOT NULL,
+    artist_id    bigint REFERENCES artists (id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    release_date date,
+    type         text NOT NULL DEFAULT 'album',
+    created_at   timestamptz,
+    updated_at   timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_albums_artist_id ON albums (artist_id);
+
+CREATE TABLE IF NOT EXISTS album_tracks (
+    id       bigserial PRIMARY KEY,
+    album_id bigint NOT NULL REFERENCES albums (id) ON DELETE CASCADE,
+    position bigint NOT NULL,
+    song_id  bigint NOT NULL REFERENCES musics (id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_album_tracks_position ON album_tracks (album_id, position);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_album_tracks_song ON album_tracks (album_id, song_id);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS playlists (
+    id         bigserial PRIMARY KEY,
+    owner      text NOT NULL,
+    name       text NOT NULL,
+    visibility text NOT NULL DEFAULT 'private',
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_playlists_owner ON playlists (owner);
+
+CREATE TABLE IF NOT EXISTS playlist_entries (
+    id          bigserial PRIMARY KEY,
+    playlist_id bigint NOT NULL REFERENCES playlists (id) ON DELETE CASCADE,
+    position    bigint NOT NULL,
+    song_id     bigint NOT NULL REFERENCES musics (id) ON DELETE CASCADE,
+    created_at  timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_playlist_entries_playlist_id ON playlist_entries (playlist_id);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS users (
+    id            bigserial PRIMARY KEY,
+    username      text NOT NULL,
+    password_hash text NOT NULL,
+    role          text NOT NULL DEFAULT 'viewer',
+    created_at    timestamptz,
+    updated_at    timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username ON users (username);
@@ -0,0 +1,4 @@
+-- Songs still in the trash would reappear, so purge them first.
+DELETE FROM musics WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_musics_deleted_at;
+ALTER TABLE musics DROP COLUMN IF EXISTS deleted_at;
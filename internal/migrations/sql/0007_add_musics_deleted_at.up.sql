@@ -0,0 +1,2 @@
+ALTER TABLE musics ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_musics_deleted_at ON musics (deleted_at);
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS song_revisions (
+    id         bigserial PRIMARY KEY,
+    song_id    bigint NOT NULL REFERENCES musics (id) ON DELETE CASCADE,
+    revision   bigint NOT NULL,
+    action     text NOT NULL,
+    author     text,
+    changes    jsonb NOT NULL DEFAULT '[]',
+    snapshot   jsonb NOT NULL,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_song_revisions_song_revision ON song_revisions (song_id, revision);
+
+-- Existing songs start their history with their current state as revision 1.
+INSERT INTO song_revisions (song_id, revision, action, author, changes, snapshot, created_at)
+SELECT id, 1, 'create', '', '[]',
+       jsonb_build_object(
+           'group', group_name,
+           'title', title,
+           'releaseDate', to_char(coalesce(release_date, '0001-01-01'::date), 'YYYY-MM-DD"T00:00:00Z"'),
+           'text', coalesce(text, ''),
+           'link', coalesce(link, ''),
+           'sources', jsonb_strip_nulls(jsonb_build_object(
+               'releaseDate', nullif(source_release_date, ''),
+               'text', nullif(source_text, ''),
+               'link', nullif(source_link, '')
+           ))
+       ),
+       coalesce(created_at, now())
+FROM musics
+WHERE NOT EXISTS (SELECT 1 FROM song_revisions r WHERE r.song_id = musics.id);
@@ -0,0 +1 @@
+ALTER TABLE musics ADD COLUMN IF NOT EXISTS synced_lyrics jsonb;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_musics_lookup_trgm;
+DROP INDEX IF EXISTS idx_musics_lookup_keys;
+ALTER TABLE musics DROP COLUMN IF EXISTS title_key;
+ALTER TABLE musics DROP COLUMN IF EXISTS group_key;
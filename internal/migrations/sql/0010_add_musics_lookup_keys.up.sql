@@ -0,0 +1,10 @@
+-- Normalized group and title (see models.NormalizeLookupKey) used by GET
+-- /info. They are filled in by the application: on every save, and for
+-- existing rows by the backfill that runs after migrating.
+ALTER TABLE musics ADD COLUMN IF NOT EXISTS group_key text NOT NULL DEFAULT '';
+ALTER TABLE musics ADD COLUMN IF NOT EXISTS title_key text NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_musics_lookup_keys ON musics (group_key, title_key);
+
+-- Trigram similarity ranks the "did you mean" suggestions.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX IF NOT EXISTS idx_musics_lookup_trgm ON musics USING GIN ((group_key || ' ' || title_key) gin_trgm_ops);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_musics_lookup_keys_unique;
@@ -0,0 +1,29 @@
+-- Songs outside the trash may not share a normalized group and title. The
+-- migrator fills in the lookup keys of existing rows just before this runs,
+-- since they are computed in Go. Existing duplicates are listed instead of
+-- failing on the index with no detail; merge or delete them and run the
+-- migration again. Only songs whose group has no letters or digits keep an
+-- empty key, and they are left out.
+DO $$
+DECLARE
+    duplicates text;
+BEGIN
+    SELECT string_agg(format('"%s" / "%s" (songs %s)', group_key, title_key, ids), ', ')
+    INTO duplicates
+    FROM (
+        SELECT group_key, title_key, string_agg(id::text, ', ' ORDER BY id) AS ids
+        FROM musics
+        WHERE deleted_at IS NULL AND group_key <> ''
+        GROUP BY group_key, title_key
+        HAVING count(*) > 1
+    ) AS duplicate_songs;
+
+    IF duplicates IS NOT NULL THEN
+        RAISE EXCEPTION 'songs with the same normalized group and title: %', duplicates
+            USING HINT = 'Merge or delete the duplicates, then run the migration again.';
+    END IF;
+END
+$$;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_musics_lookup_keys_unique ON musics (group_key, title_key)
+    WHERE deleted_at IS NULL AND group_key <> '';
@@ -0,0 +1,2 @@
+-- Incremented on every update; GET and write responses expose it as the ETag.
+ALTER TABLE musics ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+const (
+	AlbumTypeAlbum       = "album"
+	AlbumTypeSingle      = "single"
+	AlbumTypeEP          = "ep"
+	AlbumTypeCompilation = "compilation"
+)
+
+// SourceAlbum marks a song field that was defaulted from the song's album.
+const SourceAlbum = "album"
+
+type Album struct {
+	ID          uint         `json:"id" gorm:"primaryKey"`
+	Title       string       `json:"title" gorm:"not null"`
+	ArtistID    *uint        `json:"artistId,omitempty" gorm:"index"`
+	Artist      *Artist      `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	ReleaseDate time.Time    `json:"releaseDate" gorm:"type:date"`
+	Type        string       `json:"type" gorm:"not null;default:album"`
+	Tracks      []AlbumTrack `json:"tracks" gorm:"constraint:OnDelete:CASCADE"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// AlbumTrack places a song at a 1-based position on an album's tracklist.
+type AlbumTrack struct {
+	ID       uint   `json:"-" gorm:"primaryKey"`
+	AlbumID  uint   `json:"-" gorm:"not null;uniqueIndex:idx_album_tracks_position;uniqueIndex:idx_album_tracks_song"`
+	Position int    `json:"position" gorm:"not null;uniqueIndex:idx_album_tracks_position"`
+	SongID   uint   `json:"songId" gorm:"not null;uniqueIndex:idx_album_tracks_song"`
+	Song     *Music `json:"song,omitempty" gorm:"constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// API key scopes. Unlike roles they do not include each other: a key that
+// should both read and write needs both scopes.
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+	ScopeAdmin = "admin"
+)
+
+// roleScopes maps the minimum role a route requires to the scope a key needs.
+var roleScopes = map[string]string{
+	RoleViewer: ScopeRead,
+	RoleEditor: ScopeWrite,
+	RoleAdmin:  ScopeAdmin,
+}
+
+// ScopeForRole returns the scope an API key needs where a user needs minRole.
+func ScopeForRole(minRole string) string {
+	return roleScopes[minRole]
+}
+
+// Scopes is stored as a comma separated list.
+type Scopes []string
+
+func (s Scopes) Has(scope string) bool {
+	for _, granted := range s {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Scopes) Value() (driver.Value, error) {
+	return strings.Join(s, ","), nil
+}
+
+func (s *Scopes) Scan(value interface{}) error {
+	var raw string
+	switch v := value.(type) {
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	case nil:
+		raw = ""
+	default:
+		return fmt.Errorf("cannot scan %T into Scopes", value)
+	}
+
+	*s = Scopes{}
+	if raw != "" {
+		*s = strings.Split(raw, ",")
+	}
+	return nil
+}
+
+// APIKey lets a non-interactive client authenticate with the X-API-Key header.
+// Only the SHA-256 hash of the key is stored; Prefix identifies it in listings.
+type APIKey struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	Name       string     `json:"name" gorm:"not null"`
+	Prefix     string     `json:"prefix" gorm:"size:16;not null"`
+	KeyHash    string     `json:"-" gorm:"size:64;uniqueIndex;not null"`
+	Scopes     Scopes     `json:"scopes" gorm:"type:text;not null" swaggertype:"array,string"`
+	CreatedBy  string     `json:"createdBy"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Active reports whether the key may be used at now.
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
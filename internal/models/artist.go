@@ -0,0 +1,35 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+type Artist struct {
+	ID             uint   `json:"id" gorm:"primaryKey"`
+	Name           string `json:"name" gorm:"not null"`
+	NormalizedName string `json:"-" gorm:"uniqueIndex;not null"`
+	SortName       string `json:"sortName"`
+	Country        string `json:"country" gorm:"size:2"`
+	FormedYear     int    `json:"formedYear"`
+	Biography      string `json:"biography" gorm:"type:text"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// NormalizeArtistName maps spelling variants of a group name to one key, so
+// that "The Beatles", "the  beatles" and "Beatles" resolve to the same artist.
+func NormalizeArtistName(name string) string {
+	normalized := strings.Join(strings.Fields(strings.ToLower(name)), " ")
+	return strings.TrimPrefix(normalized, "the ")
+}
+
+// DefaultSortName moves a leading article to the end: "The Beatles" -> "Beatles, The".
+func DefaultSortName(name string) string {
+	name = strings.TrimSpace(name)
+	if len(name) > 4 && strings.EqualFold(name[:4], "the ") {
+		return strings.TrimSpace(name[4:]) + ", " + name[:3]
+	}
+	return name
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"gorm.io/gorm"
+)
+
+// NormalizeLookupKey reduces a group name or title to the form songs are
+// looked up by: accents are dropped, the text is NFKC-normalized and
+// case-folded, and runs of whitespace and punctuation become one space. So
+// "  Beyoncé — Halo " and "beyonce halo" have the same key.
+func NormalizeLookupKey(value string) string {
+	stripAccents := transform.Chain(norm.NFKD, runes.Remove(runes.In(unicode.Mn)), norm.NFKC)
+	stripped, _, err := transform.String(stripAccents, value)
+	if err != nil {
+		stripped = norm.NFKC.String(value)
+	}
+
+	// a Caser keeps state, so it is not shared between goroutines
+	folded := cases.Fold().String(stripped)
+	words := strings.FieldsFunc(folded, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+	})
+	return strings.Join(words, " ")
+}
+
+// SetLookupKeys derives GroupKey and TitleKey from Group and Title.
+func (m *Music) SetLookupKeys() {
+	m.GroupKey = NormalizeLookupKey(m.Group)
+	m.TitleKey = NormalizeLookupKey(m.Title)
+}
+
+// BeforeSave keeps the lookup keys in step with the group and title on every
+// create and save.
+func (m *Music) BeforeSave(tx *gorm.DB) error {
+	m.SetLookupKeys()
+	return nil
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LyricLine is one line of synchronized lyrics, shown from TimeMs
+// milliseconds into the song until the next line starts.
+type LyricLine struct {
+	TimeMs int64  `json:"timeMs"`
+	Text   string `json:"text"`
+}
+
+// Timestamp formats the line's time as an LRC timestamp, [mm:ss.xx].
+func (l LyricLine) Timestamp() string {
+	centiseconds := l.TimeMs / 10
+	return fmt.Sprintf("[%02d:%02d.%02d]", centiseconds/6000, centiseconds/100%60, centiseconds%100)
+}
+
+// LyricLines are synchronized lyrics ordered by time, stored as a JSON array.
+type LyricLines []LyricLine
+
+// String formats the lines as an LRC file.
+func (l LyricLines) String() string {
+	var b strings.Builder
+	for _, line := range l {
+		b.WriteString(line.Timestamp())
+		b.WriteString(line.Text)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (l LyricLines) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(l)
+	return string(data), err
+}
+
+func (l *LyricLines) Scan(value interface{}) error {
+	*l = nil
+	return scanJSON(value, l)
+}
+
+// ActiveAt returns the index of the line being sung at positionMs, or -1
+// before the first line starts.
+func (l LyricLines) ActiveAt(positionMs int64) int {
+	return sort.Search(len(l), func(i int) bool { return l[i].TimeMs > positionMs }) - 1
+}
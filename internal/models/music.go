@@ -1,15 +1,46 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Music struct {
 	ID          uint      `json:"id" gorm:"primaryKey"`
     Group       string    `json:"group" gorm:"column:group_name;not null"`
+	ArtistID    *uint     `json:"artistId,omitempty" gorm:"index"`
+	Artist      *Artist   `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
 	Title       string    `json:"title" gorm:"not null"`
+	// GroupKey and TitleKey are Group and Title run through
+	// NormalizeLookupKey; songs are looked up by them.
+	GroupKey    string    `json:"-" gorm:"not null;default:''"`
+	TitleKey    string    `json:"-" gorm:"not null;default:''"`
 	ReleaseDate time.Time `json:"releaseDate" gorm:"type:date"`
 	Text        string    `json:"text" gorm:"type:text"`
+	// SyncedLyrics are the time-coded lyrics uploaded as an LRC file.
+	SyncedLyrics LyricLines `json:"syncedLyrics,omitempty" gorm:"type:jsonb"`
 	Link        string    `json:"link"`
-	
+	Sources     FieldSources `json:"sources" gorm:"embedded;embeddedPrefix:source_"`
+	// Version starts at 1 and goes up with every update; it is the song's ETag.
+	Version     uint      `json:"version" gorm:"not null;default:1"`
+
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
-}
\ No newline at end of file
+	// DeletedAt is set while the song is in the trash; gorm then hides it from
+	// every query that is not Unscoped.
+	DeletedAt   gorm.DeletedAt `json:"deletedAt,omitempty" gorm:"index" swaggertype:"string" format:"date-time"`
+}
+
+// SourceManual marks a field that was set or overridden by an editor.
+const SourceManual = "manual"
+
+// SourceImport marks a field that was set by a bulk import.
+const SourceImport = "import"
+
+// FieldSources records which enrichment provider supplied each field.
+type FieldSources struct {
+	ReleaseDate string `json:"releaseDate,omitempty"`
+	Text        string `json:"text,omitempty"`
+	Link        string `json:"link,omitempty"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+const (
+	VisibilityPublic  = "public"
+	VisibilityPrivate = "private"
+)
+
+type Playlist struct {
+	ID         uint            `json:"id" gorm:"primaryKey"`
+	Owner      string          `json:"owner" gorm:"not null;index"`
+	Name       string          `json:"name" gorm:"not null"`
+	Visibility string          `json:"visibility" gorm:"not null;default:private"`
+	Entries    []PlaylistEntry `json:"entries,omitempty" gorm:"constraint:OnDelete:CASCADE"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// PlaylistEntry places a song at a 1-based position on a playlist. The same
+// song may appear more than once, so entries are addressed by their own ID.
+type PlaylistEntry struct {
+	ID         uint   `json:"id" gorm:"primaryKey"`
+	PlaylistID uint   `json:"-" gorm:"not null;index"`
+	Position   int    `json:"position" gorm:"not null"`
+	SongID     uint   `json:"songId" gorm:"not null"`
+	Song       *Music `json:"song,omitempty" gorm:"constraint:OnDelete:CASCADE"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,141 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Revision actions.
+const (
+	RevisionCreate   = "create"
+	RevisionUpdate   = "update"
+	RevisionRollback = "rollback"
+	RevisionDelete   = "delete"
+	RevisionRestore  = "restore"
+)
+
+// SongRevision records one change to a song: who made it, which fields it
+// touched and the complete state of the song afterwards. Revisions of a song
+// are numbered from 1.
+type SongRevision struct {
+	ID       uint         `json:"-" gorm:"primaryKey"`
+	SongID   uint         `json:"songId" gorm:"not null;uniqueIndex:idx_song_revisions_song_revision"`
+	Song     *Music       `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Revision int          `json:"revision" gorm:"not null;uniqueIndex:idx_song_revisions_song_revision"`
+	Action   string       `json:"action" gorm:"not null"`
+	Author   string       `json:"author"`
+	Changes  FieldChanges `json:"changes" gorm:"type:jsonb;not null"`
+	Snapshot SongSnapshot `json:"snapshot" gorm:"type:jsonb;not null"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// FieldChange is the value of one song field before and after a change.
+// Release dates are formatted as 2006-01-02.
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// FieldChanges is stored as a JSON array.
+type FieldChanges []FieldChange
+
+func (c FieldChanges) Value() (driver.Value, error) {
+	if c == nil {
+		c = FieldChanges{}
+	}
+	data, err := json.Marshal(c)
+	return string(data), err
+}
+
+func (c *FieldChanges) Scan(value interface{}) error {
+	return scanJSON(value, c)
+}
+
+// SongSnapshot is the editable state of a song at one revision.
+type SongSnapshot struct {
+	Group       string       `json:"group"`
+	Title       string       `json:"title"`
+	ReleaseDate time.Time    `json:"releaseDate"`
+	Text        string       `json:"text"`
+	Link        string       `json:"link"`
+	Sources     FieldSources `json:"sources"`
+	// SyncedLyrics is missing from revisions recorded before synchronized
+	// lyrics existed.
+	SyncedLyrics LyricLines `json:"syncedLyrics,omitempty"`
+}
+
+// SnapshotOf captures the editable fields of song.
+func SnapshotOf(song *Music) SongSnapshot {
+	return SongSnapshot{
+		Group:        song.Group,
+		Title:        song.Title,
+		ReleaseDate:  song.ReleaseDate,
+		Text:         song.Text,
+		Link:         song.Link,
+		Sources:      song.Sources,
+		SyncedLyrics: song.SyncedLyrics,
+	}
+}
+
+// ApplyTo overwrites the editable fields of song with the snapshot.
+func (s SongSnapshot) ApplyTo(song *Music) {
+	song.Group = s.Group
+	song.Title = s.Title
+	song.ReleaseDate = s.ReleaseDate
+	song.Text = s.Text
+	song.Link = s.Link
+	song.Sources = s.Sources
+	song.SyncedLyrics = s.SyncedLyrics
+}
+
+// Diff lists the fields that differ between s and other, with s as the
+// before side. Sources are not compared; they follow the fields they describe.
+func (s SongSnapshot) Diff(other SongSnapshot) FieldChanges {
+	changes := FieldChanges{}
+	add := func(field, before, after string) {
+		if before != after {
+			changes = append(changes, FieldChange{Field: field, Before: before, After: after})
+		}
+	}
+
+	add("group", s.Group, other.Group)
+	add("title", s.Title, other.Title)
+	add("releaseDate", formatDate(s.ReleaseDate), formatDate(other.ReleaseDate))
+	add("text", s.Text, other.Text)
+	add("link", s.Link, other.Link)
+	add("syncedLyrics", s.SyncedLyrics.String(), other.SyncedLyrics.String())
+	return changes
+}
+
+func (s SongSnapshot) Value() (driver.Value, error) {
+	data, err := json.Marshal(s)
+	return string(data), err
+}
+
+func (s *SongSnapshot) Scan(value interface{}) error {
+	return scanJSON(value, s)
+}
+
+func formatDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format("2006-01-02")
+}
+
+func scanJSON(value interface{}, dest interface{}) error {
+	switch v := value.(type) {
+	case string:
+		return json.Unmarshal([]byte(v), dest)
+	case []byte:
+		return json.Unmarshal(v, dest)
+	case nil:
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into %T", value, dest)
+	}
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// SongFilter selects songs. Group and Title match case-insensitive
+// substrings, the release date range is inclusive and leaves out songs with
+// an unknown release date, and HasLyrics counts plain or synchronized lyrics.
+// Zero fields do not filter.
+type SongFilter struct {
+	Group        string
+	Title        string
+	ArtistID     *uint
+	ReleasedFrom *time.Time
+	ReleasedTo   *time.Time
+	HasLyrics    *bool
+	HasLink      *bool
+}
+
+// Fields songs can be sorted by, as named in the sort parameter of GET /music.
+const (
+	SortTitle       = "title"
+	SortGroup       = "group"
+	SortReleaseDate = "releaseDate"
+	SortCreatedAt   = "createdAt"
+)
+
+// SongSortFields lists the fields songs can be sorted by.
+var SongSortFields = []string{SortTitle, SortGroup, SortReleaseDate, SortCreatedAt}
+
+// SongSort is one key of a sort order. Songs that tie on every key are
+// ordered by ID.
+type SongSort struct {
+	Field string
+	Desc  bool
+}
+
+// String formats the key as it is written in the sort parameter.
+func (k SongSort) String() string {
+	if k.Desc {
+		return k.Field + ":desc"
+	}
+	return k.Field + ":asc"
+}
+
+// SortValue is the value song is ordered by for this key: the lower-cased
+// title or group, the release date as 2006-01-02, or the creation time.
+func (k SongSort) SortValue(song Music) interface{} {
+	switch k.Field {
+	case SortTitle:
+		return strings.ToLower(song.Title)
+	case SortGroup:
+		return strings.ToLower(song.Group)
+	case SortReleaseDate:
+		return song.ReleaseDate.Format("2006-01-02")
+	case SortCreatedAt:
+		return song.CreatedAt
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+const (
+	RoleViewer = "viewer"
+	RoleEditor = "editor"
+	RoleAdmin  = "admin"
+)
+
+// roleRanks orders roles so that every role includes the ones below it.
+var roleRanks = map[string]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+}
+
+type User struct {
+	ID           uint   `json:"id" gorm:"primaryKey"`
+	Username     string `json:"username" gorm:"uniqueIndex;not null"`
+	PasswordHash string `json:"-" gorm:"not null"`
+	Role         string `json:"role" gorm:"not null;default:viewer"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// RoleAtLeast reports whether role grants everything minRole does.
+func RoleAtLeast(role, minRole string) bool {
+	return roleRanks[role] > 0 && roleRanks[role] >= roleRanks[minRole]
+}
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"gorm.io/gorm"
+)
+
+type AlbumRepository struct {
+	DB *gorm.DB
+}
+
+func NewAlbumRepository(db *gorm.DB) *AlbumRepository {
+	return &AlbumRepository{DB: db}
+}
+
+// methods:
+func (repo *AlbumRepository) AddAlbum(album *models.Album) error {
+	return repo.DB.Create(album).Error
+}
+
+func (repo *AlbumRepository) GetAlbumByID(id uint) (*models.Album, error) {
+	var album models.Album
+	err := repo.DB.
+		Preload("Tracks", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		Preload("Tracks.Song").
+		First(&album, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &album, nil
+}
+
+func (repo *AlbumRepository) ListAlbums(limit, offset int) ([]models.Album, error) {
+	var albums []models.Album
+	err := repo.DB.Order("release_date DESC, id").Limit(limit).Offset(offset).Find(&albums).Error
+	if err != nil {
+		return nil, err
+	}
+	return albums, nil
+}
+
+func (repo *AlbumRepository) CountAlbums() (int, error) {
+	var count int64
+	err := repo.DB.Model(&models.Album{}).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (repo *AlbumRepository) CountAlbumsByArtist(artistID uint) (int, error) {
+	var count int64
+	err := repo.DB.Model(&models.Album{}).Where("artist_id = ?", artistID).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// ReplaceTracks swaps the whole tracklist in one transaction; songIDs are in play order.
+func (repo *AlbumRepository) ReplaceTracks(albumID uint, songIDs []uint) error {
+	return repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("album_id = ?", albumID).Delete(&models.AlbumTrack{}).Error; err != nil {
+			return err
+		}
+		if len(songIDs) == 0 {
+			return nil
+		}
+
+		tracks := make([]models.AlbumTrack, len(songIDs))
+		for i, songID := range songIDs {
+			tracks[i] = models.AlbumTrack{AlbumID: albumID, SongID: songID, Position: i + 1}
+		}
+		return tx.Create(&tracks).Error
+	})
+}
@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository struct {
+	DB *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) *APIKeyRepository {
+	return &APIKeyRepository{DB: db}
+}
+
+// methods:
+func (repo *APIKeyRepository) AddAPIKey(key *models.APIKey) error {
+	return repo.DB.Create(key).Error
+}
+
+func (repo *APIKeyRepository) GetAPIKeyByID(id uint) (*models.APIKey, error) {
+	var key models.APIKey
+	err := repo.DB.First(&key, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (repo *APIKeyRepository) GetAPIKeyByHash(keyHash string) (*models.APIKey, error) {
+	var key models.APIKey
+	err := repo.DB.Where("key_hash = ?", keyHash).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (repo *APIKeyRepository) ListAPIKeys(limit, offset int) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	err := repo.DB.Order("id").Limit(limit).Offset(offset).Find(&keys).Error
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (repo *APIKeyRepository) CountAPIKeys() (int, error) {
+	var count int64
+	err := repo.DB.Model(&models.APIKey{}).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (repo *APIKeyRepository) RevokeAPIKey(id uint, revokedAt time.Time) error {
+	return repo.updateTimestamp(id, "revoked_at", revokedAt)
+}
+
+// TouchAPIKey records when the key was last used.
+func (repo *APIKeyRepository) TouchAPIKey(id uint, usedAt time.Time) error {
+	return repo.updateTimestamp(id, "last_used_at", usedAt)
+}
+
+func (repo *APIKeyRepository) updateTimestamp(id uint, column string, at time.Time) error {
+	result := repo.DB.Model(&models.APIKey{}).Where("id = ?", id).Update(column, at)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
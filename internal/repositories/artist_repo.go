@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"log"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"gorm.io/gorm"
+)
+
+type ArtistRepository struct {
+	DB *gorm.DB
+}
+
+func NewArtistRepository(db *gorm.DB) *ArtistRepository {
+	return &ArtistRepository{DB: db}
+}
+
+// BackfillArtists creates an artist for every distinct group_name that has none
+// yet and links the songs to it, including the ones in the trash so that they
+// come back linked when restored. It is safe to run on every boot.
+func BackfillArtists(db *gorm.DB) error {
+	var groups []string
+	err := db.Unscoped().Model(&models.Music{}).
+		Where("artist_id IS NULL").
+		Distinct("group_name").
+		Pluck("group_name", &groups).Error
+	if err != nil {
+		return err
+	}
+
+	repo := NewArtistRepository(db)
+	for _, group := range groups {
+		artist, err := repo.GetArtistByNormalizedName(models.NormalizeArtistName(group))
+		if err == gorm.ErrRecordNotFound {
+			artist = &models.Artist{
+				Name:           group,
+				NormalizedName: models.NormalizeArtistName(group),
+				SortName:       models.DefaultSortName(group),
+			}
+			err = repo.AddArtist(artist)
+		}
+		if err != nil {
+			return err
+		}
+
+		err = db.Unscoped().Model(&models.Music{}).
+			Where("artist_id IS NULL AND group_name = ?", group).
+			Update("artist_id", artist.ID).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(groups) > 0 {
+		log.Printf("BackfillArtists: Linked songs from %d group names to artists", len(groups))
+	}
+	return nil
+}
+
+// methods:
+func (repo *ArtistRepository) AddArtist(artist *models.Artist) error {
+	return repo.DB.Create(artist).Error
+}
+
+func (repo *ArtistRepository) GetArtistByID(id uint) (*models.Artist, error) {
+	var artist models.Artist
+	err := repo.DB.First(&artist, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &artist, nil
+}
+
+func (repo *ArtistRepository) GetArtistByNormalizedName(normalizedName string) (*models.Artist, error) {
+	var artist models.Artist
+	err := repo.DB.Where("normalized_name = ?", normalizedName).First(&artist).Error
+	if err != nil {
+		return nil, err
+	}
+	return &artist, nil
+}
+
+func (repo *ArtistRepository) UpdateArtist(artist *models.Artist) error {
+	return repo.DB.Save(artist).Error
+}
+
+func (repo *ArtistRepository) DeleteArtist(id uint) error {
+	return repo.DB.Delete(&models.Artist{}, id).Error
+}
+
+func (repo *ArtistRepository) ListArtists(limit, offset int) ([]models.Artist, error) {
+	var artists []models.Artist
+	err := repo.DB.Order("sort_name, id").Limit(limit).Offset(offset).Find(&artists).Error
+	if err != nil {
+		return nil, err
+	}
+	return artists, nil
+}
+
+func (repo *ArtistRepository) CountArtists() (int, error) {
+	var count int64
+	err := repo.DB.Model(&models.Artist{}).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
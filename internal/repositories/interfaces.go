@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+)
+
+// ErrVersionConflict is returned when a song changed since the version the
+// write was based on.
+var ErrVersionConflict = errors.New("song was changed since it was read")
+
+// MusicStore is the persistent storage for songs. DeleteSong only moves a
+// song to the trash; PurgeSongs removes trashed songs for good. UpdateSong and
+// SaveSongs only write songs still at their Version, and move them to the
+// next one; AddSong starts songs at version 1. DeleteSong checks the version
+// unless it is 0. DeleteSong and RestoreSong move the song to the next version
+// too.
+type MusicStore interface {
+	AddSong(song *models.Music) error
+	GetSong(group, title string) (*models.Music, error)
+	SuggestSongs(group, title string, limit int) ([]models.Music, error)
+	GetSongByID(id uint) (*models.Music, error)
+	UpdateSong(song *models.Music) error
+	SaveSongs(songs []*models.Music) error
+	DeleteSong(id uint, version uint) error
+	ListSongs(filter models.SongFilter, sort []models.SongSort, limit, offset int) ([]models.Music, error)
+	CountSongs(filter models.SongFilter) (int, error)
+	ListSongsByCursor(filter models.SongFilter, sort []models.SongSort, cursor SongCursor, limit int) ([]models.Music, error)
+	EachSong(filter models.SongFilter, fn func(song models.Music) error) error
+	SearchSongs(terms []string, limit, offset int) ([]SongSearchHit, int, error)
+	ListDeletedSongs(filter models.SongFilter, limit, offset int) ([]models.Music, error)
+	CountDeletedSongs(filter models.SongFilter) (int, error)
+	RestoreSong(id uint) error
+	PurgeSongs(deletedBefore time.Time) (int, error)
+}
+
+// RevisionStore is the change history of songs. AddRevision assigns the
+// revision number.
+type RevisionStore interface {
+	AddRevision(revision *models.SongRevision) error
+	GetRevision(songID uint, revision int) (*models.SongRevision, error)
+	LatestRevision(songID uint) (*models.SongRevision, error)
+	ListRevisions(songID uint, limit, offset int) ([]models.SongRevision, error)
+	CountRevisions(songID uint) (int, error)
+}
+
+// SongTransactor runs fn with a song store and a revision store whose writes
+// are committed together, or not at all when fn returns an error.
+type SongTransactor interface {
+	InTransaction(fn func(songs MusicStore, revisions RevisionStore) error) error
+}
+
+// SongCursor is a position in the songs in sort order: the sort values of a
+// song, one per sort key (see models.SongSort.SortValue), and its ID.
+// ListSongsByCursor returns the songs after it, or before it when Backward is
+// set; a zero ID starts from the first song.
+type SongCursor struct {
+	ID       uint
+	Values   []interface{}
+	Backward bool
+}
+
+// ArtistStore is the persistent storage for artists.
+type ArtistStore interface {
+	AddArtist(artist *models.Artist) error
+	GetArtistByID(id uint) (*models.Artist, error)
+	GetArtistByNormalizedName(normalizedName string) (*models.Artist, error)
+	UpdateArtist(artist *models.Artist) error
+	DeleteArtist(id uint) error
+	ListArtists(limit, offset int) ([]models.Artist, error)
+	CountArtists() (int, error)
+}
+
+// AlbumStore is the persistent storage for albums and their tracklists.
+type AlbumStore interface {
+	AddAlbum(album *models.Album) error
+	GetAlbumByID(id uint) (*models.Album, error)
+	ListAlbums(limit, offset int) ([]models.Album, error)
+	CountAlbums() (int, error)
+	CountAlbumsByArtist(artistID uint) (int, error)
+	ReplaceTracks(albumID uint, songIDs []uint) error
+}
+
+// PlaylistStore is the persistent storage for playlists and their entries.
+type PlaylistStore interface {
+	AddPlaylist(playlist *models.Playlist) error
+	GetPlaylistByID(id uint) (*models.Playlist, error)
+	ListPlaylists(filter map[string]interface{}, limit, offset int) ([]models.Playlist, error)
+	CountPlaylists(filter map[string]interface{}) (int, error)
+	SaveEntries(playlistID uint, entries []models.PlaylistEntry) error
+}
+
+// UserStore is the persistent storage for user accounts.
+type UserStore interface {
+	AddUser(user *models.User) error
+	GetUserByUsername(username string) (*models.User, error)
+}
+
+// APIKeyStore is the persistent storage for API keys.
+type APIKeyStore interface {
+	AddAPIKey(key *models.APIKey) error
+	GetAPIKeyByID(id uint) (*models.APIKey, error)
+	GetAPIKeyByHash(keyHash string) (*models.APIKey, error)
+	ListAPIKeys(limit, offset int) ([]models.APIKey, error)
+	CountAPIKeys() (int, error)
+	RevokeAPIKey(id uint, revokedAt time.Time) error
+	TouchAPIKey(id uint, usedAt time.Time) error
+}
+
+// RateLimitStore counts requests per key in a sliding window.
+type RateLimitStore interface {
+	Hit(key string, limit int, window time.Duration, now time.Time) (RateLimitResult, error)
+}
+
+// SongCache is the key/value cache in front of MusicStore.
+type SongCache interface {
+	SetSongCache(key string, value string, ttl time.Duration) error
+	GetSongCache(key string) (string, error)
+	DeleteSongCache(key string) error
+}
+
+var (
+	_ MusicStore     = (*MusicRepository)(nil)
+	_ MusicStore     = (*InMemoryMusicRepository)(nil)
+	_ RevisionStore  = (*RevisionRepository)(nil)
+	_ RevisionStore  = (*InMemoryRevisionRepository)(nil)
+	_ SongTransactor = (*SongTransaction)(nil)
+	_ SongTransactor = (*InMemorySongTransaction)(nil)
+	_ ArtistStore    = (*ArtistRepository)(nil)
+	_ ArtistStore    = (*InMemoryArtistRepository)(nil)
+	_ AlbumStore     = (*AlbumRepository)(nil)
+	_ AlbumStore     = (*InMemoryAlbumRepository)(nil)
+	_ PlaylistStore  = (*PlaylistRepository)(nil)
+	_ PlaylistStore  = (*InMemoryPlaylistRepository)(nil)
+	_ UserStore      = (*UserRepository)(nil)
+	_ UserStore      = (*InMemoryUserRepository)(nil)
+	_ APIKeyStore    = (*APIKeyRepository)(nil)
+	_ APIKeyStore    = (*InMemoryAPIKeyRepository)(nil)
+	_ RateLimitStore = (*RateLimitRepository)(nil)
+	_ RateLimitStore = (*InMemoryRateLimitRepository)(nil)
+	_ SongCache      = (*CacheRepository)(nil)
+	_ SongCache      = (*InMemoryCacheRepository)(nil)
+)
@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"gorm.io/gorm"
+)
+
+// InMemoryAlbumRepository is an AlbumStore kept in a map. Track songs are
+// resolved through the MusicStore it was built with.
+type InMemoryAlbumRepository struct {
+	mu     sync.RWMutex
+	albums map[uint]models.Album
+	nextID uint
+	songs  MusicStore
+}
+
+func NewInMemoryAlbumRepository(songs MusicStore) *InMemoryAlbumRepository {
+	return &InMemoryAlbumRepository{albums: map[uint]models.Album{}, nextID: 1, songs: songs}
+}
+
+// methods:
+func (repo *InMemoryAlbumRepository) AddAlbum(album *models.Album) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	now := time.Now()
+	album.ID = repo.nextID
+	album.CreatedAt = now
+	album.UpdatedAt = now
+	repo.nextID++
+
+	stored := *album
+	stored.Tracks = append([]models.AlbumTrack(nil), album.Tracks...)
+	for i := range stored.Tracks {
+		stored.Tracks[i].AlbumID = album.ID
+		stored.Tracks[i].Song = nil
+	}
+	repo.albums[album.ID] = stored
+	return nil
+}
+
+func (repo *InMemoryAlbumRepository) GetAlbumByID(id uint) (*models.Album, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	album, ok := repo.albums[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	album.Tracks = append([]models.AlbumTrack(nil), album.Tracks...)
+	sort.Slice(album.Tracks, func(i, j int) bool { return album.Tracks[i].Position < album.Tracks[j].Position })
+	for i := range album.Tracks {
+		if song, err := repo.songs.GetSongByID(album.Tracks[i].SongID); err == nil {
+			album.Tracks[i].Song = song
+		}
+	}
+	return &album, nil
+}
+
+func (repo *InMemoryAlbumRepository) ListAlbums(limit, offset int) ([]models.Album, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	albums := make([]models.Album, 0, len(repo.albums))
+	for _, album := range repo.albums {
+		album.Tracks = nil
+		albums = append(albums, album)
+	}
+	sort.Slice(albums, func(i, j int) bool {
+		if !albums[i].ReleaseDate.Equal(albums[j].ReleaseDate) {
+			return albums[i].ReleaseDate.After(albums[j].ReleaseDate)
+		}
+		return albums[i].ID < albums[j].ID
+	})
+
+	if offset >= len(albums) {
+		return []models.Album{}, nil
+	}
+	end := offset + limit
+	if end > len(albums) {
+		end = len(albums)
+	}
+	return albums[offset:end], nil
+}
+
+func (repo *InMemoryAlbumRepository) CountAlbums() (int, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	return len(repo.albums), nil
+}
+
+func (repo *InMemoryAlbumRepository) CountAlbumsByArtist(artistID uint) (int, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	count := 0
+	for _, album := range repo.albums {
+		if album.ArtistID != nil && *album.ArtistID == artistID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (repo *InMemoryAlbumRepository) ReplaceTracks(albumID uint, songIDs []uint) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	album, ok := repo.albums[albumID]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+
+	album.Tracks = make([]models.AlbumTrack, len(songIDs))
+	for i, songID := range songIDs {
+		album.Tracks[i] = models.AlbumTrack{AlbumID: albumID, SongID: songID, Position: i + 1}
+	}
+	album.UpdatedAt = time.Now()
+	repo.albums[albumID] = album
+	return nil
+}
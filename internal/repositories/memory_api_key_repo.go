@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"sync"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"gorm.io/gorm"
+)
+
+// InMemoryAPIKeyRepository is an APIKeyStore kept in a slice ordered by ID.
+type InMemoryAPIKeyRepository struct {
+	mu     sync.RWMutex
+	keys   []models.APIKey
+	nextID uint
+}
+
+func NewInMemoryAPIKeyRepository() *InMemoryAPIKeyRepository {
+	return &InMemoryAPIKeyRepository{nextID: 1}
+}
+
+// methods:
+func (repo *InMemoryAPIKeyRepository) AddAPIKey(key *models.APIKey) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, existing := range repo.keys {
+		if existing.KeyHash == key.KeyHash {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+
+	key.ID = repo.nextID
+	key.CreatedAt = time.Now()
+	repo.nextID++
+	repo.keys = append(repo.keys, *key)
+	return nil
+}
+
+func (repo *InMemoryAPIKeyRepository) GetAPIKeyByID(id uint) (*models.APIKey, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	return repo.find(func(key *models.APIKey) bool { return key.ID == id })
+}
+
+func (repo *InMemoryAPIKeyRepository) GetAPIKeyByHash(keyHash string) (*models.APIKey, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	return repo.find(func(key *models.APIKey) bool { return key.KeyHash == keyHash })
+}
+
+func (repo *InMemoryAPIKeyRepository) ListAPIKeys(limit, offset int) ([]models.APIKey, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	if offset >= len(repo.keys) {
+		return []models.APIKey{}, nil
+	}
+	end := offset + limit
+	if end > len(repo.keys) {
+		end = len(repo.keys)
+	}
+	return append([]models.APIKey(nil), repo.keys[offset:end]...), nil
+}
+
+func (repo *InMemoryAPIKeyRepository) CountAPIKeys() (int, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	return len(repo.keys), nil
+}
+
+func (repo *InMemoryAPIKeyRepository) RevokeAPIKey(id uint, revokedAt time.Time) error {
+	return repo.update(id, func(key *models.APIKey) { key.RevokedAt = &revokedAt })
+}
+
+func (repo *InMemoryAPIKeyRepository) TouchAPIKey(id uint, usedAt time.Time) error {
+	return repo.update(id, func(key *models.APIKey) { key.LastUsedAt = &usedAt })
+}
+
+func (repo *InMemoryAPIKeyRepository) find(match func(key *models.APIKey) bool) (*models.APIKey, error) {
+	for i := range repo.keys {
+		if match(&repo.keys[i]) {
+			key := repo.keys[i]
+			return &key, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (repo *InMemoryAPIKeyRepository) update(id uint, apply func(key *models.APIKey)) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for i := range repo.keys {
+		if repo.keys[i].ID == id {
+			apply(&repo.keys[i])
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"gorm.io/gorm"
+)
+
+// InMemoryArtistRepository is an ArtistStore kept in a map.
+type InMemoryArtistRepository struct {
+	mu      sync.RWMutex
+	artists map[uint]models.Artist
+	nextID  uint
+}
+
+func NewInMemoryArtistRepository() *InMemoryArtistRepository {
+	return &InMemoryArtistRepository{artists: map[uint]models.Artist{}, nextID: 1}
+}
+
+// methods:
+func (repo *InMemoryArtistRepository) AddArtist(artist *models.Artist) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, existing := range repo.artists {
+		if existing.NormalizedName == artist.NormalizedName {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+
+	now := time.Now()
+	artist.ID = repo.nextID
+	artist.CreatedAt = now
+	artist.UpdatedAt = now
+	repo.nextID++
+	repo.artists[artist.ID] = *artist
+	return nil
+}
+
+func (repo *InMemoryArtistRepository) GetArtistByID(id uint) (*models.Artist, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	artist, ok := repo.artists[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &artist, nil
+}
+
+func (repo *InMemoryArtistRepository) GetArtistByNormalizedName(normalizedName string) (*models.Artist, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	for _, artist := range repo.artists {
+		if artist.NormalizedName == normalizedName {
+			return &artist, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (repo *InMemoryArtistRepository) UpdateArtist(artist *models.Artist) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	artist.UpdatedAt = time.Now()
+	repo.artists[artist.ID] = *artist
+	return nil
+}
+
+func (repo *InMemoryArtistRepository) DeleteArtist(id uint) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	delete(repo.artists, id)
+	return nil
+}
+
+func (repo *InMemoryArtistRepository) ListArtists(limit, offset int) ([]models.Artist, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	artists := make([]models.Artist, 0, len(repo.artists))
+	for _, artist := range repo.artists {
+		artists = append(artists, artist)
+	}
+	sort.Slice(artists, func(i, j int) bool {
+		if artists[i].SortName != artists[j].SortName {
+			return artists[i].SortName < artists[j].SortName
+		}
+		return artists[i].ID < artists[j].ID
+	})
+
+	if offset >= len(artists) {
+		return []models.Artist{}, nil
+	}
+	end := offset + limit
+	if end > len(artists) {
+		end = len(artists)
+	}
+	return artists[offset:end], nil
+}
+
+func (repo *InMemoryArtistRepository) CountArtists() (int, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	return len(repo.artists), nil
+}
@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"sync"
+	"time"
+)
+
+type cacheEntry struct {
+	value     string
+	expiresAt time.Time
+}
+
+// InMemoryCacheRepository is a SongCache kept in a map, used in tests and
+// local runs without Redis. Like CacheRepository, a miss returns "" and no error.
+type InMemoryCacheRepository struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+func NewInMemoryCacheRepository() *InMemoryCacheRepository {
+	return &InMemoryCacheRepository{entries: map[string]cacheEntry{}}
+}
+
+// methods:
+func (repo *InMemoryCacheRepository) SetSongCache(key string, value string, ttl time.Duration) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	entry := cacheEntry{value: value}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	repo.entries[key] = entry
+	return nil
+}
+
+func (repo *InMemoryCacheRepository) GetSongCache(key string) (string, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	entry, ok := repo.entries[key]
+	if !ok {
+		return "", nil
+	}
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		delete(repo.entries, key)
+		return "", nil
+	}
+	return entry.value, nil
+}
+
+func (repo *InMemoryCacheRepository) DeleteSongCache(key string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	delete(repo.entries, key)
+	return nil
+}
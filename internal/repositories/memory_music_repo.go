@@ -0,0 +1,449 @@
+package repositories
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"gorm.io/gorm"
+)
+
+// InMemoryMusicRepository is a MusicStore kept in a map, used in tests and
+// local runs without PostgreSQL. Missing songs return gorm.ErrRecordNotFound
+// just like MusicRepository.
+type InMemoryMusicRepository struct {
+	mu     sync.RWMutex
+	songs  map[uint]models.Music
+	nextID uint
+}
+
+func NewInMemoryMusicRepository() *InMemoryMusicRepository {
+	return &InMemoryMusicRepository{songs: map[uint]models.Music{}, nextID: 1}
+}
+
+func (repo *InMemoryMusicRepository) checkpoint() func() {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	songs := make(map[uint]models.Music, len(repo.songs))
+	for id, song := range repo.songs {
+		songs[id] = song
+	}
+	nextID := repo.nextID
+	return func() {
+		repo.mu.Lock()
+		defer repo.mu.Unlock()
+		repo.songs, repo.nextID = songs, nextID
+	}
+}
+
+// methods:
+func (repo *InMemoryMusicRepository) AddSong(song *models.Music) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	song.SetLookupKeys()
+	if repo.duplicates(*song) {
+		return gorm.ErrDuplicatedKey
+	}
+
+	now := time.Now()
+	song.ID = repo.nextID
+	song.Version = 1
+	song.CreatedAt = now
+	song.UpdatedAt = now
+	repo.nextID++
+	repo.songs[song.ID] = *song
+	return nil
+}
+
+func (repo *InMemoryMusicRepository) GetSong(group, title string) (*models.Music, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	groupKey, titleKey := models.NormalizeLookupKey(group), models.NormalizeLookupKey(title)
+	for _, song := range repo.sortedSongs() {
+		if song.GroupKey == groupKey && song.TitleKey == titleKey {
+			return &song, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// SuggestSongs ranks songs the way pg_trgm does for MusicRepository.
+func (repo *InMemoryMusicRepository) SuggestSongs(group, title string, limit int) ([]models.Music, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	key := trigrams(models.NormalizeLookupKey(group) + " " + models.NormalizeLookupKey(title))
+	songs := []models.Music{}
+	scores := map[uint]float64{}
+	for _, song := range repo.sortedSongs() {
+		score := trigramSimilarity(key, trigrams(song.GroupKey+" "+song.TitleKey))
+		if score >= trigramThreshold {
+			songs = append(songs, song)
+			scores[song.ID] = score
+		}
+	}
+	sort.SliceStable(songs, func(i, j int) bool {
+		return scores[songs[i].ID] > scores[songs[j].ID]
+	})
+
+	if len(songs) > limit {
+		songs = songs[:limit]
+	}
+	return songs, nil
+}
+
+func (repo *InMemoryMusicRepository) GetSongByID(id uint) (*models.Music, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	song, ok := repo.songs[id]
+	if !ok || song.DeletedAt.Valid {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &song, nil
+}
+
+func (repo *InMemoryMusicRepository) UpdateSong(song *models.Music) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	song.SetLookupKeys()
+	if repo.duplicates(*song) {
+		return gorm.ErrDuplicatedKey
+	}
+
+	if song.ID == 0 {
+		song.ID = repo.nextID
+		song.Version = 0
+		song.CreatedAt = time.Now()
+		repo.nextID++
+	} else if stored, ok := repo.songs[song.ID]; !ok || stored.DeletedAt.Valid || stored.Version != song.Version {
+		return ErrVersionConflict
+	}
+	song.Version++
+	song.UpdatedAt = time.Now()
+	repo.songs[song.ID] = *song
+	return nil
+}
+
+// SaveSongs saves the songs one by one; there are no transactions in memory.
+func (repo *InMemoryMusicRepository) SaveSongs(songs []*models.Music) error {
+	for _, song := range songs {
+		if err := repo.UpdateSong(song); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (repo *InMemoryMusicRepository) DeleteSong(id uint, version uint) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	song, ok := repo.songs[id]
+	if !ok || song.DeletedAt.Valid {
+		if version != 0 {
+			return ErrVersionConflict
+		}
+		return nil
+	}
+	if version != 0 && song.Version != version {
+		return ErrVersionConflict
+	}
+	song.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	song.Version++
+	song.UpdatedAt = time.Now()
+	repo.songs[id] = song
+	return nil
+}
+
+func (repo *InMemoryMusicRepository) ListSongs(filter models.SongFilter, sort []models.SongSort, limit, offset int) ([]models.Music, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	songs := []models.Music{}
+	for _, song := range repo.sortedSongs() {
+		if matchesFilter(song, filter) {
+			songs = append(songs, song)
+		}
+	}
+	sortSongsInMemory(songs, sort)
+
+	if offset >= len(songs) {
+		return []models.Music{}, nil
+	}
+	end := offset + limit
+	if limit < 0 || end > len(songs) {
+		end = len(songs)
+	}
+	return songs[offset:end], nil
+}
+
+func (repo *InMemoryMusicRepository) ListSongsByCursor(filter models.SongFilter, sort []models.SongSort, cursor SongCursor, limit int) ([]models.Music, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	if cursor.ID != 0 && len(cursor.Values) != len(sort) {
+		return nil, fmt.Errorf("cursor has %d sort values, want %d", len(cursor.Values), len(sort))
+	}
+
+	songs := []models.Music{}
+	for _, song := range repo.sortedSongs() {
+		if !matchesFilter(song, filter) {
+			continue
+		}
+		if cursor.ID == 0 {
+			songs = append(songs, song)
+			continue
+		}
+		position := compareSongPositions(sort, songSortValues(song, sort), song.ID, cursor.Values, cursor.ID)
+		if cursor.Backward && position < 0 || !cursor.Backward && position > 0 {
+			songs = append(songs, song)
+		}
+	}
+	sortSongsInMemory(songs, sort)
+
+	if len(songs) > limit {
+		if cursor.Backward {
+			songs = songs[len(songs)-limit:]
+		} else {
+			songs = songs[:limit]
+		}
+	}
+	return songs, nil
+}
+
+func (repo *InMemoryMusicRepository) CountSongs(filter models.SongFilter) (int, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	count := 0
+	for _, song := range repo.sortedSongs() {
+		if matchesFilter(song, filter) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// EachSong calls fn on a copy of the matching songs, so fn may use the
+// repository.
+func (repo *InMemoryMusicRepository) EachSong(filter models.SongFilter, fn func(song models.Music) error) error {
+	repo.mu.RLock()
+	songs := []models.Music{}
+	for _, song := range repo.sortedSongs() {
+		if matchesFilter(song, filter) {
+			songs = append(songs, song)
+		}
+	}
+	repo.mu.RUnlock()
+
+	for _, song := range songs {
+		if err := fn(song); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (repo *InMemoryMusicRepository) ListDeletedSongs(filter models.SongFilter, limit, offset int) ([]models.Music, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	songs := []models.Music{}
+	for _, song := range repo.deletedSongs() {
+		if matchesFilter(song, filter) {
+			songs = append(songs, song)
+		}
+	}
+
+	if offset >= len(songs) {
+		return []models.Music{}, nil
+	}
+	end := offset + limit
+	if limit < 0 || end > len(songs) {
+		end = len(songs)
+	}
+	return songs[offset:end], nil
+}
+
+func (repo *InMemoryMusicRepository) CountDeletedSongs(filter models.SongFilter) (int, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	count := 0
+	for _, song := range repo.deletedSongs() {
+		if matchesFilter(song, filter) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (repo *InMemoryMusicRepository) RestoreSong(id uint) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	song, ok := repo.songs[id]
+	if !ok || !song.DeletedAt.Valid {
+		return gorm.ErrRecordNotFound
+	}
+	if repo.duplicates(song) {
+		return gorm.ErrDuplicatedKey
+	}
+	song.DeletedAt = gorm.DeletedAt{}
+	song.Version++
+	song.UpdatedAt = time.Now()
+	repo.songs[id] = song
+	return nil
+}
+
+func (repo *InMemoryMusicRepository) PurgeSongs(deletedBefore time.Time) (int, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	purged := 0
+	for id, song := range repo.songs {
+		if song.DeletedAt.Valid && song.DeletedAt.Time.Before(deletedBefore) {
+			delete(repo.songs, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// SearchSongs approximates the Postgres ranking: every term must prefix-match
+// a word, and title, group and lyrics matches weigh 1.0, 0.4 and 0.1.
+func (repo *InMemoryMusicRepository) SearchSongs(terms []string, limit, offset int) ([]SongSearchHit, int, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	hits := []SongSearchHit{}
+	for _, song := range repo.sortedSongs() {
+		rank := 0.0
+		matched := true
+		for _, term := range terms {
+			termRank := 0.0
+			if textMatchesTerm(song.Title, term) {
+				termRank += 1.0
+			}
+			if textMatchesTerm(song.Group, term) {
+				termRank += 0.4
+			}
+			if textMatchesTerm(song.Text, term) {
+				termRank += 0.1
+			}
+			if termRank == 0 {
+				matched = false
+				break
+			}
+			rank += termRank
+		}
+		if matched && len(terms) > 0 {
+			hits = append(hits, SongSearchHit{Music: song, Rank: rank})
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Rank > hits[j].Rank })
+
+	total := len(hits)
+	if offset >= total {
+		return []SongSearchHit{}, total, nil
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	return hits[offset:end], total, nil
+}
+
+// sortedSongs returns the songs that are not in the trash, ordered by ID.
+func (repo *InMemoryMusicRepository) sortedSongs() []models.Music {
+	songs := make([]models.Music, 0, len(repo.songs))
+	for _, song := range repo.songs {
+		if !song.DeletedAt.Valid {
+			songs = append(songs, song)
+		}
+	}
+	sort.Slice(songs, func(i, j int) bool { return songs[i].ID < songs[j].ID })
+	return songs
+}
+
+// duplicates mirrors the unique index on the lookup keys of songs outside
+// the trash.
+func (repo *InMemoryMusicRepository) duplicates(song models.Music) bool {
+	if song.GroupKey == "" {
+		return false
+	}
+	for _, other := range repo.songs {
+		if other.ID != song.ID && !other.DeletedAt.Valid && other.GroupKey == song.GroupKey && other.TitleKey == song.TitleKey {
+			return true
+		}
+	}
+	return false
+}
+
+// deletedSongs returns the trashed songs, most recently deleted first.
+func (repo *InMemoryMusicRepository) deletedSongs() []models.Music {
+	songs := []models.Music{}
+	for _, song := range repo.songs {
+		if song.DeletedAt.Valid {
+			songs = append(songs, song)
+		}
+	}
+	sort.Slice(songs, func(i, j int) bool {
+		if !songs[i].DeletedAt.Time.Equal(songs[j].DeletedAt.Time) {
+			return songs[i].DeletedAt.Time.After(songs[j].DeletedAt.Time)
+		}
+		return songs[i].ID < songs[j].ID
+	})
+	return songs
+}
+
+// sortSongsInMemory orders songs, already ordered by ID, the way sortSongs
+// orders them in SQL.
+func sortSongsInMemory(songs []models.Music, sortKeys []models.SongSort) {
+	if len(sortKeys) == 0 {
+		return
+	}
+	sort.SliceStable(songs, func(i, j int) bool {
+		return compareSongPositions(sortKeys, songSortValues(songs[i], sortKeys), songs[i].ID, songSortValues(songs[j], sortKeys), songs[j].ID) < 0
+	})
+}
+
+// trigramThreshold is the default similarity threshold of the pg_trgm %
+// operator.
+const trigramThreshold = 0.3
+
+// trigrams splits text into the trigram set pg_trgm uses: every word padded
+// with two spaces in front and one behind.
+func trigrams(text string) map[string]bool {
+	set := map[string]bool{}
+	for _, word := range strings.Fields(text) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = true
+		}
+	}
+	return set
+}
+
+func trigramSimilarity(a, b map[string]bool) float64 {
+	shared := 0
+	for trigram := range a {
+		if b[trigram] {
+			shared++
+		}
+	}
+	total := len(a) + len(b) - shared
+	if total == 0 {
+		return 0
+	}
+	return float64(shared) / float64(total)
+}
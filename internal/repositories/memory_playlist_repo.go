@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"gorm.io/gorm"
+)
+
+// InMemoryPlaylistRepository is a PlaylistStore kept in a map. Entry songs are
+// resolved through the MusicStore it was built with.
+type InMemoryPlaylistRepository struct {
+	mu          sync.RWMutex
+	playlists   map[uint]models.Playlist
+	nextID      uint
+	nextEntryID uint
+	songs       MusicStore
+}
+
+func NewInMemoryPlaylistRepository(songs MusicStore) *InMemoryPlaylistRepository {
+	return &InMemoryPlaylistRepository{playlists: map[uint]models.Playlist{}, nextID: 1, nextEntryID: 1, songs: songs}
+}
+
+// methods:
+func (repo *InMemoryPlaylistRepository) AddPlaylist(playlist *models.Playlist) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	now := time.Now()
+	playlist.ID = repo.nextID
+	playlist.CreatedAt = now
+	playlist.UpdatedAt = now
+	repo.nextID++
+
+	stored := *playlist
+	stored.Entries = nil
+	repo.playlists[playlist.ID] = stored
+	return nil
+}
+
+func (repo *InMemoryPlaylistRepository) GetPlaylistByID(id uint) (*models.Playlist, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	playlist, ok := repo.playlists[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	playlist.Entries = append([]models.PlaylistEntry(nil), playlist.Entries...)
+	sort.Slice(playlist.Entries, func(i, j int) bool { return playlist.Entries[i].Position < playlist.Entries[j].Position })
+	for i := range playlist.Entries {
+		if song, err := repo.songs.GetSongByID(playlist.Entries[i].SongID); err == nil {
+			playlist.Entries[i].Song = song
+		}
+	}
+	return &playlist, nil
+}
+
+func (repo *InMemoryPlaylistRepository) ListPlaylists(filter map[string]interface{}, limit, offset int) ([]models.Playlist, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	playlists := repo.filteredPlaylists(filter)
+	if offset >= len(playlists) {
+		return []models.Playlist{}, nil
+	}
+	end := offset + limit
+	if end > len(playlists) {
+		end = len(playlists)
+	}
+	return playlists[offset:end], nil
+}
+
+func (repo *InMemoryPlaylistRepository) CountPlaylists(filter map[string]interface{}) (int, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	return len(repo.filteredPlaylists(filter)), nil
+}
+
+func (repo *InMemoryPlaylistRepository) SaveEntries(playlistID uint, entries []models.PlaylistEntry) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	playlist, ok := repo.playlists[playlistID]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+
+	saved := make([]models.PlaylistEntry, len(entries))
+	for i := range entries {
+		entries[i].PlaylistID = playlistID
+		if entries[i].ID == 0 {
+			entries[i].ID = repo.nextEntryID
+			entries[i].CreatedAt = time.Now()
+			repo.nextEntryID++
+		}
+		saved[i] = entries[i]
+		saved[i].Song = nil
+	}
+	playlist.Entries = saved
+	playlist.UpdatedAt = time.Now()
+	repo.playlists[playlistID] = playlist
+	return nil
+}
+
+func (repo *InMemoryPlaylistRepository) filteredPlaylists(filter map[string]interface{}) []models.Playlist {
+	playlists := []models.Playlist{}
+	for _, playlist := range repo.playlists {
+		matched := true
+		for key, value := range filter {
+			switch key {
+			case "owner":
+				matched = matched && playlist.Owner == value
+			case "visibility":
+				matched = matched && playlist.Visibility == value
+			default:
+				matched = false
+			}
+		}
+		if matched {
+			playlist.Entries = nil
+			playlists = append(playlists, playlist)
+		}
+	}
+	sort.Slice(playlists, func(i, j int) bool { return playlists[i].ID < playlists[j].ID })
+	return playlists
+}
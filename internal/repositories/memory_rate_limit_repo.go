@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"sync"
+	"time"
+)
+
+// InMemoryRateLimitRepository is a RateLimitStore kept in a map of request
+// times, with the same sliding-window-log semantics as RateLimitRepository.
+type InMemoryRateLimitRepository struct {
+	mu   sync.Mutex
+	hits map[string][]time.Time
+}
+
+func NewInMemoryRateLimitRepository() *InMemoryRateLimitRepository {
+	return &InMemoryRateLimitRepository{hits: map[string][]time.Time{}}
+}
+
+// methods:
+func (repo *InMemoryRateLimitRepository) Hit(key string, limit int, window time.Duration, now time.Time) (RateLimitResult, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	hits := repo.hits[key][:0]
+	for _, hit := range repo.hits[key] {
+		if hit.After(now.Add(-window)) {
+			hits = append(hits, hit)
+		}
+	}
+
+	allowed := len(hits) < limit
+	if allowed {
+		hits = append(hits, now)
+	}
+	repo.hits[key] = hits
+
+	result := RateLimitResult{Allowed: allowed, Remaining: limit - len(hits)}
+	if len(hits) > 0 {
+		result.ResetAfter = hits[0].Add(window).Sub(now)
+	}
+	return result, nil
+}
@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"sync"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"gorm.io/gorm"
+)
+
+// InMemoryRevisionRepository is a RevisionStore keeping each song's revisions
+// in the order they were added.
+type InMemoryRevisionRepository struct {
+	mu        sync.RWMutex
+	revisions map[uint][]models.SongRevision
+	nextID    uint
+}
+
+func NewInMemoryRevisionRepository() *InMemoryRevisionRepository {
+	return &InMemoryRevisionRepository{revisions: map[uint][]models.SongRevision{}, nextID: 1}
+}
+
+func (repo *InMemoryRevisionRepository) checkpoint() func() {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	revisions := make(map[uint][]models.SongRevision, len(repo.revisions))
+	for songID, songRevisions := range repo.revisions {
+		revisions[songID] = append([]models.SongRevision(nil), songRevisions...)
+	}
+	nextID := repo.nextID
+	return func() {
+		repo.mu.Lock()
+		defer repo.mu.Unlock()
+		repo.revisions, repo.nextID = revisions, nextID
+	}
+}
+
+// methods:
+func (repo *InMemoryRevisionRepository) AddRevision(revision *models.SongRevision) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	revision.ID = repo.nextID
+	revision.Revision = len(repo.revisions[revision.SongID]) + 1
+	revision.CreatedAt = time.Now()
+	repo.nextID++
+	repo.revisions[revision.SongID] = append(repo.revisions[revision.SongID], *revision)
+	return nil
+}
+
+func (repo *InMemoryRevisionRepository) GetRevision(songID uint, revision int) (*models.SongRevision, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	revisions := repo.revisions[songID]
+	if revision < 1 || revision > len(revisions) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	found := revisions[revision-1]
+	return &found, nil
+}
+
+func (repo *InMemoryRevisionRepository) LatestRevision(songID uint) (*models.SongRevision, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	revisions := repo.revisions[songID]
+	if len(revisions) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	found := revisions[len(revisions)-1]
+	return &found, nil
+}
+
+func (repo *InMemoryRevisionRepository) ListRevisions(songID uint, limit, offset int) ([]models.SongRevision, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	stored := repo.revisions[songID]
+	revisions := []models.SongRevision{}
+	for i := len(stored) - 1 - offset; i >= 0 && len(revisions) < limit; i-- {
+		revisions = append(revisions, stored[i])
+	}
+	return revisions, nil
+}
+
+func (repo *InMemoryRevisionRepository) CountRevisions(songID uint) (int, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	return len(repo.revisions[songID]), nil
+}
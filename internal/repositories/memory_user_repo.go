@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"sync"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"gorm.io/gorm"
+)
+
+// InMemoryUserRepository is a UserStore kept in a map keyed by username.
+type InMemoryUserRepository struct {
+	mu     sync.RWMutex
+	users  map[string]models.User
+	nextID uint
+}
+
+func NewInMemoryUserRepository() *InMemoryUserRepository {
+	return &InMemoryUserRepository{users: map[string]models.User{}, nextID: 1}
+}
+
+// methods:
+func (repo *InMemoryUserRepository) AddUser(user *models.User) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, exists := repo.users[user.Username]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+
+	now := time.Now()
+	user.ID = repo.nextID
+	user.CreatedAt = now
+	user.UpdatedAt = now
+	repo.nextID++
+	repo.users[user.Username] = *user
+	return nil
+}
+
+func (repo *InMemoryUserRepository) GetUserByUsername(username string) (*models.User, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	user, ok := repo.users[username]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &user, nil
+}
@@ -1,8 +1,12 @@
 package repositories
 
 import (
+	"errors"
+	"fmt"
 	"log"
+	"time"
 
+	"github.com/srmbackisdeveloper/test-music-info/internal/migrations"
 	"github.com/srmbackisdeveloper/test-music-info/internal/models"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -13,7 +17,9 @@ type MusicRepository struct {
 }
 
 func NewPostgresDB(dsn string) (*gorm.DB, error) {
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	// TranslateError turns unique violations into gorm.ErrDuplicatedKey, the
+	// error the in-memory stores return too.
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		return nil, err
 	}
@@ -29,12 +35,96 @@ func NewPostgresDB(dsn string) (*gorm.DB, error) {
 
 	log.Println("Connected to PostgreSQL")
     
-    if err := db.AutoMigrate(&models.Music{}); err != nil {
+    return db, nil
+}
+
+// MigrateDB applies the pending schema migrations, links songs stored
+// before artists existed to their artist and fills in missing lookup keys.
+func MigrateDB(db *gorm.DB) error {
+    all, err := migrations.All()
+    if err != nil {
+        return err
+    }
+
+    migrator, err := NewMigrator(db, all)
+    if err != nil {
+        return err
+    }
+
+    applied, err := migrator.Up(0)
+    if err != nil {
+        return err
+    }
+    log.Printf("Database migrated successfully, %d migrations applied", len(applied))
+
+    return Backfill(db, migrator)
+}
+
+// Backfill runs the Go backfills whose migrations migrator has applied, so
+// that it also works on a database migrated only part of the way.
+func Backfill(db *gorm.DB, migrator *migrations.Migrator) error {
+    statuses, err := migrator.Status()
+    if err != nil {
+        return err
+    }
+    applied := map[string]bool{}
+    for _, status := range statuses {
+        applied[status.Name] = status.AppliedAt != nil
+    }
+
+    if applied["create_artists_and_musics"] {
+        if err := BackfillArtists(db); err != nil {
+            return fmt.Errorf("failed to backfill artists: %w", err)
+        }
+    }
+    // Until the unique index exists its BeforeUp hook fills the keys in.
+    if applied["add_musics_lookup_keys_unique"] {
+        if err := BackfillSongLookupKeys(db); err != nil {
+            return fmt.Errorf("failed to backfill song lookup keys: %w", err)
+        }
+    }
+    return nil
+}
+
+// NewMigrator returns the migrator for migrations that also runs the Go
+// backfills they depend on: lookup keys are computed in Go, so they are filled
+// in before the unique index on them is created and its duplicate check runs.
+func NewMigrator(db *gorm.DB, all []migrations.Migration) (*migrations.Migrator, error) {
+    sqlDB, err := db.DB()
+    if err != nil {
         return nil, err
     }
-    log.Println("Database migrated successfully")
 
-    return db, nil
+    migrator := migrations.NewMigrator(sqlDB, all)
+    migrator.BeforeUp = map[string]func() error{
+        "add_musics_lookup_keys_unique": func() error { return BackfillSongLookupKeys(db) },
+    }
+    return migrator, nil
+}
+
+// BackfillSongLookupKeys sets the lookup keys of songs stored before they
+// existed, trashed songs included. A song that would duplicate another one
+// fails the backfill, naming it, rather than being left unreachable by name.
+// It is safe to run on every boot.
+func BackfillSongLookupKeys(db *gorm.DB) error {
+	var songs []models.Music
+	return db.Unscoped().Select("id", "group_name", "title").
+		Where("group_key = '' OR title_key = ''").
+		FindInBatches(&songs, 500, func(tx *gorm.DB, batch int) error {
+			for _, song := range songs {
+				song.SetLookupKeys()
+				err := db.Unscoped().Model(&models.Music{}).Where("id = ?", song.ID).
+					UpdateColumns(map[string]interface{}{"group_key": song.GroupKey, "title_key": song.TitleKey}).Error
+				if errors.Is(err, gorm.ErrDuplicatedKey) {
+					return fmt.Errorf("song %d has the same normalized group and title as another song; merge or delete it: %w", song.ID, err)
+				}
+				if err != nil {
+					return err
+				}
+			}
+			log.Printf("BackfillSongLookupKeys: Set lookup keys of %d songs", len(songs))
+			return nil
+		}).Error
 }
 
 func NewMusicRepository(db *gorm.DB) *MusicRepository {
@@ -43,33 +133,100 @@ func NewMusicRepository(db *gorm.DB) *MusicRepository {
 
 // methods:
 func (repo *MusicRepository) AddSong(song *models.Music) error {
+    song.Version = 1
     return repo.DB.Create(song).Error
 }
 
+// GetSong finds a song by its normalized group and title, so case, accents,
+// punctuation and extra whitespace do not matter.
 func (repo *MusicRepository) GetSong(group, title string) (*models.Music, error) {
     var song models.Music
-    err := repo.DB.Where("group_name = ? AND title = ?", group, title).First(&song).Error
+    err := repo.DB.Where("group_key = ? AND title_key = ?", models.NormalizeLookupKey(group), models.NormalizeLookupKey(title)).
+        Order("id").First(&song).Error
     if err != nil {
         return nil, err
     }
     return &song, nil
 }
 
+// SuggestSongs lists up to limit songs whose group and title look like the
+// given ones, most similar first, by trigram similarity of the lookup keys.
+func (repo *MusicRepository) SuggestSongs(group, title string, limit int) ([]models.Music, error) {
+	var songs []models.Music
+
+	key := models.NormalizeLookupKey(group) + " " + models.NormalizeLookupKey(title)
+	err := repo.DB.Where("(group_key || ' ' || title_key) % ?", key).
+		Order(gorm.Expr("similarity(group_key || ' ' || title_key, ?) DESC, id", key)).
+		Limit(limit).Find(&songs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return songs, nil
+}
+
 func (repo *MusicRepository) UpdateSong(song *models.Music) error {
-    return repo.DB.Save(song).Error
+    return saveSongVersion(repo.DB, song)
 }
 
-func (repo *MusicRepository) DeleteSong(id uint) error {
-    return repo.DB.Delete(&models.Music{}, id).Error
+// saveSongVersion creates a song without an ID at version 1, and otherwise
+// updates it if it is still at song.Version, moving it to the next version.
+// Selecting the columns keeps Save from falling back to an insert when the
+// version does not match.
+func saveSongVersion(db *gorm.DB, song *models.Music) error {
+	if song.ID == 0 {
+		song.Version = 1
+		return db.Create(song).Error
+	}
+
+	version := song.Version
+	song.Version++
+	result := db.Select("*").Where("version = ?", version).Save(song)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrVersionConflict
+	}
+	if result.Error != nil {
+		song.Version = version
+	}
+	return result.Error
 }
 
-func (repo *MusicRepository) ListSongs(filter map[string]interface{}, limit, offset int) ([]models.Music, error) {
+// SaveSongs creates the songs without an ID and updates the others, all in
+// one transaction.
+func (repo *MusicRepository) SaveSongs(songs []*models.Music) error {
+	return repo.DB.Transaction(func(tx *gorm.DB) error {
+		for _, song := range songs {
+			if err := saveSongVersion(tx, song); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (repo *MusicRepository) DeleteSong(id uint, version uint) error {
+    query := repo.DB.Model(&models.Music{}).Where("id = ?", id)
+    if version != 0 {
+        query = query.Where("version = ?", version)
+    }
+
+    result := query.Updates(map[string]interface{}{
+        "deleted_at": time.Now(),
+        "version":    gorm.Expr("version + 1"),
+    })
+    if result.Error == nil && version != 0 && result.RowsAffected == 0 {
+        return ErrVersionConflict
+    }
+    return result.Error
+}
+
+// ListSongs orders songs by sort and then by ID. Sort fields must be among
+// models.SongSortFields.
+func (repo *MusicRepository) ListSongs(filter models.SongFilter, sort []models.SongSort, limit, offset int) ([]models.Music, error) {
 	var songs []models.Music
 
-	query := repo.DB.Model(&models.Music{})
-	for key, value := range filter {
-		query = query.Where(key+" = ?", value)
-	}
+	query := filterSongs(repo.DB.Model(&models.Music{}), filter)
+	query = sortSongs(query, sort, false)
 
 	err := query.Limit(limit).Offset(offset).Find(&songs).Error
 	if err != nil {
@@ -79,13 +236,39 @@ func (repo *MusicRepository) ListSongs(filter map[string]interface{}, limit, off
 	return songs, nil
 }
 
-func (repo *MusicRepository) CountSongs(filter map[string]interface{}) (int, error) {
-	var count int64
+// ListSongsByCursor pages with a keyset on the sort keys and the primary key,
+// so it costs the same on every page and does not shift when songs are added
+// or removed. Songs are always returned in sort order.
+func (repo *MusicRepository) ListSongsByCursor(filter models.SongFilter, sort []models.SongSort, cursor SongCursor, limit int) ([]models.Music, error) {
+	var songs []models.Music
 
-	query := repo.DB.Model(&models.Music{})
-	for key, value := range filter {
-		query = query.Where(key+" = ?", value)
+	query, err := afterCursor(filterSongs(repo.DB.Model(&models.Music{}), filter), sort, cursor)
+	if err != nil {
+		return nil, err
 	}
+	query = sortSongs(query, sort, cursor.Backward)
+
+	err = query.Limit(limit).Find(&songs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor.Backward {
+		reverseSongs(songs)
+	}
+	return songs, nil
+}
+
+func reverseSongs(songs []models.Music) {
+	for i, j := 0, len(songs)-1; i < j; i, j = i+1, j-1 {
+		songs[i], songs[j] = songs[j], songs[i]
+	}
+}
+
+func (repo *MusicRepository) CountSongs(filter models.SongFilter) (int, error) {
+	var count int64
+
+	query := filterSongs(repo.DB.Model(&models.Music{}), filter)
 
 	err := query.Count(&count).Error
 	if err != nil {
@@ -95,6 +278,29 @@ func (repo *MusicRepository) CountSongs(filter map[string]interface{}) (int, err
 	return int(count), nil
 }
 
+// EachSong calls fn for every song matching filter, ordered by ID. Rows are
+// read from the result cursor one at a time instead of being loaded into
+// memory at once; an error from fn stops the iteration and is returned.
+func (repo *MusicRepository) EachSong(filter models.SongFilter, fn func(song models.Music) error) error {
+	query := filterSongs(repo.DB.Model(&models.Music{}), filter)
+
+	rows, err := query.Order("id").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var song models.Music
+		if err := repo.DB.ScanRows(rows, &song); err != nil {
+			return err
+		}
+		if err := fn(song); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
 
 func (repo *MusicRepository) GetSongByID(id uint) (*models.Music, error) {
 	var song models.Music
@@ -105,6 +311,89 @@ func (repo *MusicRepository) GetSongByID(id uint) (*models.Music, error) {
 	return &song, nil
 }
 
+// ListDeletedSongs lists trashed songs, most recently deleted first.
+func (repo *MusicRepository) ListDeletedSongs(filter models.SongFilter, limit, offset int) ([]models.Music, error) {
+	var songs []models.Music
+
+	query := filterSongs(repo.DB.Unscoped().Model(&models.Music{}).Where("deleted_at IS NOT NULL"), filter)
+
+	err := query.Order("deleted_at DESC, id").Limit(limit).Offset(offset).Find(&songs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return songs, nil
+}
+
+func (repo *MusicRepository) CountDeletedSongs(filter models.SongFilter) (int, error) {
+	var count int64
+
+	query := filterSongs(repo.DB.Unscoped().Model(&models.Music{}).Where("deleted_at IS NOT NULL"), filter)
+
+	err := query.Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+// RestoreSong moves a song out of the trash. Songs that are not in the trash
+// return gorm.ErrRecordNotFound.
+func (repo *MusicRepository) RestoreSong(id uint) error {
+	result := repo.DB.Unscoped().Model(&models.Music{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// PurgeSongs permanently deletes songs trashed before deletedBefore; their
+// album tracks and playlist entries go with them.
+func (repo *MusicRepository) PurgeSongs(deletedBefore time.Time) (int, error) {
+	result := repo.DB.Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).
+		Delete(&models.Music{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return int(result.RowsAffected), nil
+}
+
+// SearchSongs ranks songs by full-text relevance using prefix matching on every term.
+func (repo *MusicRepository) SearchSongs(terms []string, limit, offset int) ([]SongSearchHit, int, error) {
+	var hits []SongSearchHit
+	var count int64
+
+	tsQuery := prefixTSQuery(terms)
+	err := repo.DB.Model(&models.Music{}).
+		Where("search_vector @@ to_tsquery('simple', ?)", tsQuery).
+		Count(&count).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = repo.DB.Model(&models.Music{}).
+		Select("musics.*, ts_rank(search_vector, to_tsquery('simple', ?)) AS rank", tsQuery).
+		Where("search_vector @@ to_tsquery('simple', ?)", tsQuery).
+		Order("rank DESC, id").
+		Limit(limit).Offset(offset).
+		Find(&hits).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return hits, int(count), nil
+}
+
 // populate some data: Обогащенную информацию положить в БД postgres 
 func (repo *MusicRepository) SeedData() error {
 	// Define sample songs
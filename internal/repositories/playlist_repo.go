@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"gorm.io/gorm"
+)
+
+type PlaylistRepository struct {
+	DB *gorm.DB
+}
+
+func NewPlaylistRepository(db *gorm.DB) *PlaylistRepository {
+	return &PlaylistRepository{DB: db}
+}
+
+// methods:
+func (repo *PlaylistRepository) AddPlaylist(playlist *models.Playlist) error {
+	return repo.DB.Create(playlist).Error
+}
+
+func (repo *PlaylistRepository) GetPlaylistByID(id uint) (*models.Playlist, error) {
+	var playlist models.Playlist
+	err := repo.DB.
+		Preload("Entries", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		Preload("Entries.Song").
+		First(&playlist, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &playlist, nil
+}
+
+func (repo *PlaylistRepository) ListPlaylists(filter map[string]interface{}, limit, offset int) ([]models.Playlist, error) {
+	var playlists []models.Playlist
+
+	query := repo.DB.Model(&models.Playlist{})
+	for key, value := range filter {
+		query = query.Where(key+" = ?", value)
+	}
+
+	err := query.Order("id").Limit(limit).Offset(offset).Find(&playlists).Error
+	if err != nil {
+		return nil, err
+	}
+	return playlists, nil
+}
+
+func (repo *PlaylistRepository) CountPlaylists(filter map[string]interface{}) (int, error) {
+	var count int64
+
+	query := repo.DB.Model(&models.Playlist{})
+	for key, value := range filter {
+		query = query.Where(key+" = ?", value)
+	}
+
+	err := query.Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// SaveEntries writes a playlist's entries in one transaction: entries missing
+// from the list are deleted, new ones (ID 0) created and the rest repositioned.
+func (repo *PlaylistRepository) SaveEntries(playlistID uint, entries []models.PlaylistEntry) error {
+	return repo.DB.Transaction(func(tx *gorm.DB) error {
+		keep := []uint{0}
+		for _, entry := range entries {
+			keep = append(keep, entry.ID)
+		}
+		err := tx.Where("playlist_id = ? AND id NOT IN ?", playlistID, keep).
+			Delete(&models.PlaylistEntry{}).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range entries {
+			entries[i].PlaylistID = playlistID
+			if err := tx.Omit("Song").Save(&entries[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RateLimitResult is the outcome of recording one request in a window.
+type RateLimitResult struct {
+	Allowed   bool
+	Remaining int
+	// ResetAfter is how long until the oldest request in the window expires
+	// and frees a slot.
+	ResetAfter time.Duration
+}
+
+// slidingWindowScript keeps one sorted-set member per request, scored by its
+// time in milliseconds, and only adds the new one while under the limit.
+var slidingWindowScript = redis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+local count = redis.call('ZCARD', key)
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', key, now, ARGV[4])
+	count = count + 1
+	allowed = 1
+end
+redis.call('PEXPIRE', key, window)
+
+local reset = 0
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+return {allowed, limit - count, reset}
+`)
+
+// RateLimitRepository is a sliding-window-log rate limiter stored in Redis.
+type RateLimitRepository struct {
+	Client  *redis.Client
+	counter uint64
+}
+
+func NewRateLimitRepository(client *redis.Client) *RateLimitRepository {
+	return &RateLimitRepository{Client: client}
+}
+
+// methods:
+func (repo *RateLimitRepository) Hit(key string, limit int, window time.Duration, now time.Time) (RateLimitResult, error) {
+	ctx := context.Background()
+	member := fmt.Sprintf("%d-%d", now.UnixNano(), atomic.AddUint64(&repo.counter, 1))
+
+	values, err := slidingWindowScript.Run(ctx, repo.Client, []string{"ratelimit:" + key},
+		now.UnixMilli(), window.Milliseconds(), limit, member).Int64Slice()
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+
+	return RateLimitResult{
+		Allowed:    values[0] == 1,
+		Remaining:  int(values[1]),
+		ResetAfter: time.Duration(values[2]) * time.Millisecond,
+	}, nil
+}
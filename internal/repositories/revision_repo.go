@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"gorm.io/gorm"
+)
+
+type RevisionRepository struct {
+	DB *gorm.DB
+}
+
+func NewRevisionRepository(db *gorm.DB) *RevisionRepository {
+	return &RevisionRepository{DB: db}
+}
+
+// methods:
+// AddRevision numbers the revision after the song's latest one. Concurrent
+// writers racing for the same number fail on the unique index.
+func (repo *RevisionRepository) AddRevision(revision *models.SongRevision) error {
+	return repo.DB.Transaction(func(tx *gorm.DB) error {
+		var latest int
+		err := tx.Model(&models.SongRevision{}).
+			Where("song_id = ?", revision.SongID).
+			Select("COALESCE(MAX(revision), 0)").
+			Scan(&latest).Error
+		if err != nil {
+			return err
+		}
+
+		revision.Revision = latest + 1
+		return tx.Create(revision).Error
+	})
+}
+
+func (repo *RevisionRepository) GetRevision(songID uint, revision int) (*models.SongRevision, error) {
+	var found models.SongRevision
+	err := repo.DB.Where("song_id = ? AND revision = ?", songID, revision).First(&found).Error
+	if err != nil {
+		return nil, err
+	}
+	return &found, nil
+}
+
+func (repo *RevisionRepository) LatestRevision(songID uint) (*models.SongRevision, error) {
+	var found models.SongRevision
+	err := repo.DB.Where("song_id = ?", songID).Order("revision DESC").First(&found).Error
+	if err != nil {
+		return nil, err
+	}
+	return &found, nil
+}
+
+// ListRevisions lists a song's revisions, newest first.
+func (repo *RevisionRepository) ListRevisions(songID uint, limit, offset int) ([]models.SongRevision, error) {
+	var revisions []models.SongRevision
+	err := repo.DB.Where("song_id = ?", songID).
+		Order("revision DESC").
+		Limit(limit).Offset(offset).
+		Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+func (repo *RevisionRepository) CountRevisions(songID uint) (int, error) {
+	var count int64
+	err := repo.DB.Model(&models.SongRevision{}).Where("song_id = ?", songID).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
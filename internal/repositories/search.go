@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+)
+
+// SongSearchHit is a song matched by SearchSongs together with its relevance.
+type SongSearchHit struct {
+	models.Music
+	Rank float64 `gorm:"column:rank"`
+}
+
+// ParseSearchTerms lower-cases a free-text query and keeps only its letter
+// and digit runs, which makes the terms safe to embed in a tsquery.
+func ParseSearchTerms(query string) []string {
+	return splitWords(strings.ToLower(query))
+}
+
+func splitWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// WordMatchesTerm reports whether word starts with term, ignoring case.
+func WordMatchesTerm(word, term string) bool {
+	return strings.HasPrefix(strings.ToLower(word), term)
+}
+
+func textMatchesTerm(text, term string) bool {
+	for _, word := range splitWords(text) {
+		if WordMatchesTerm(word, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// prefixTSQuery turns already-sanitized terms into "term1:* & term2:*".
+func prefixTSQuery(terms []string) string {
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		parts[i] = term + ":*"
+	}
+	return strings.Join(parts, " & ")
+}
@@ -0,0 +1,195 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"gorm.io/gorm"
+)
+
+// songSortColumns maps the sortable fields to the SQL they order by; it is
+// the only way sort fields reach a query.
+var songSortColumns = map[string]string{
+	models.SortTitle:       "LOWER(title)",
+	models.SortGroup:       "LOWER(group_name)",
+	models.SortReleaseDate: "COALESCE(release_date, DATE '0001-01-01')",
+	models.SortCreatedAt:   "created_at",
+}
+
+const (
+	knownReleaseDateSQL = "COALESCE(release_date, DATE '0001-01-01') > DATE '0001-01-01'"
+	hasLyricsSQL        = "(COALESCE(text, '') <> '' OR COALESCE(jsonb_array_length(synced_lyrics), 0) > 0)"
+	hasLinkSQL          = "COALESCE(link, '') <> ''"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func containsPattern(value string) string {
+	return "%" + likeEscaper.Replace(value) + "%"
+}
+
+// filterSongs adds the conditions of filter to query.
+func filterSongs(query *gorm.DB, filter models.SongFilter) *gorm.DB {
+	if filter.Group != "" {
+		query = query.Where(`group_name ILIKE ? ESCAPE '\'`, containsPattern(filter.Group))
+	}
+	if filter.Title != "" {
+		query = query.Where(`title ILIKE ? ESCAPE '\'`, containsPattern(filter.Title))
+	}
+	if filter.ArtistID != nil {
+		query = query.Where("artist_id = ?", *filter.ArtistID)
+	}
+	if filter.ReleasedFrom != nil || filter.ReleasedTo != nil {
+		query = query.Where(knownReleaseDateSQL)
+	}
+	if filter.ReleasedFrom != nil {
+		query = query.Where("release_date >= ?", filter.ReleasedFrom.Format("2006-01-02"))
+	}
+	if filter.ReleasedTo != nil {
+		query = query.Where("release_date <= ?", filter.ReleasedTo.Format("2006-01-02"))
+	}
+	if filter.HasLyrics != nil {
+		query = query.Where(negateUnless(hasLyricsSQL, *filter.HasLyrics))
+	}
+	if filter.HasLink != nil {
+		query = query.Where(negateUnless(hasLinkSQL, *filter.HasLink))
+	}
+	return query
+}
+
+func negateUnless(condition string, keep bool) string {
+	if keep {
+		return condition
+	}
+	return "NOT " + condition
+}
+
+// sortSongs orders query by sort and then by ID, reversed when backward.
+func sortSongs(query *gorm.DB, sort []models.SongSort, backward bool) *gorm.DB {
+	for _, key := range sort {
+		query = query.Order(songSortColumns[key.Field] + sortDirection(key.Desc != backward))
+	}
+	return query.Order("id" + sortDirection(backward))
+}
+
+func sortDirection(desc bool) string {
+	if desc {
+		return " DESC"
+	}
+	return " ASC"
+}
+
+// afterCursor restricts query to the songs that come after cursor in the
+// sort order, or before it when cursor.Backward is set.
+func afterCursor(query *gorm.DB, sort []models.SongSort, cursor SongCursor) (*gorm.DB, error) {
+	if cursor.ID == 0 {
+		return query, nil
+	}
+	if len(cursor.Values) != len(sort) {
+		return nil, fmt.Errorf("cursor has %d sort values, want %d", len(cursor.Values), len(sort))
+	}
+
+	// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ... OR (k1 = v1 AND ... AND id > v)
+	columns := make([]string, 0, len(sort)+1)
+	descending := make([]bool, 0, len(sort)+1)
+	for _, key := range sort {
+		columns = append(columns, songSortColumns[key.Field])
+		descending = append(descending, key.Desc)
+	}
+	columns = append(columns, "id")
+	descending = append(descending, false)
+	values := append(append([]interface{}{}, cursor.Values...), cursor.ID)
+
+	var alternatives []string
+	var args []interface{}
+	for i := range columns {
+		var parts []string
+		for j := 0; j < i; j++ {
+			parts = append(parts, columns[j]+" = ?")
+			args = append(args, values[j])
+		}
+		operator := " > ?"
+		if descending[i] != cursor.Backward {
+			operator = " < ?"
+		}
+		parts = append(parts, columns[i]+operator)
+		args = append(args, values[i])
+		alternatives = append(alternatives, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	return query.Where("("+strings.Join(alternatives, " OR ")+")", args...), nil
+}
+
+// matchesFilter is filterSongs for songs held in memory.
+func matchesFilter(song models.Music, filter models.SongFilter) bool {
+	if filter.Group != "" && !strings.Contains(strings.ToLower(song.Group), strings.ToLower(filter.Group)) {
+		return false
+	}
+	if filter.Title != "" && !strings.Contains(strings.ToLower(song.Title), strings.ToLower(filter.Title)) {
+		return false
+	}
+	if filter.ArtistID != nil && (song.ArtistID == nil || *song.ArtistID != *filter.ArtistID) {
+		return false
+	}
+	releaseDate := song.ReleaseDate.Format("2006-01-02")
+	if (filter.ReleasedFrom != nil || filter.ReleasedTo != nil) && song.ReleaseDate.IsZero() {
+		return false
+	}
+	if filter.ReleasedFrom != nil && releaseDate < filter.ReleasedFrom.Format("2006-01-02") {
+		return false
+	}
+	if filter.ReleasedTo != nil && releaseDate > filter.ReleasedTo.Format("2006-01-02") {
+		return false
+	}
+	hasLyrics := song.Text != "" || len(song.SyncedLyrics) > 0
+	if filter.HasLyrics != nil && hasLyrics != *filter.HasLyrics {
+		return false
+	}
+	if filter.HasLink != nil && (song.Link != "") != *filter.HasLink {
+		return false
+	}
+	return true
+}
+
+// compareSongPositions orders two positions, each given by its sort values
+// and ID, the way sortSongs does.
+func compareSongPositions(sort []models.SongSort, aValues []interface{}, aID uint, bValues []interface{}, bID uint) int {
+	for i, key := range sort {
+		result := compareSortValues(aValues[i], bValues[i])
+		if key.Desc {
+			result = -result
+		}
+		if result != 0 {
+			return result
+		}
+	}
+	switch {
+	case aID < bID:
+		return -1
+	case aID > bID:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareSortValues(a, b interface{}) int {
+	switch a := a.(type) {
+	case string:
+		return strings.Compare(a, b.(string))
+	case time.Time:
+		return a.Compare(b.(time.Time))
+	default:
+		return 0
+	}
+}
+
+func songSortValues(song models.Music, sort []models.SongSort) []interface{} {
+	values := make([]interface{}, len(sort))
+	for i, key := range sort {
+		values[i] = key.SortValue(song)
+	}
+	return values
+}
@@ -0,0 +1,53 @@
+package repositories
+
+import "gorm.io/gorm"
+
+// SongTransaction is the SongTransactor over PostgreSQL.
+type SongTransaction struct {
+	DB *gorm.DB
+}
+
+func NewSongTransaction(db *gorm.DB) *SongTransaction {
+	return &SongTransaction{DB: db}
+}
+
+func (t *SongTransaction) InTransaction(fn func(songs MusicStore, revisions RevisionStore) error) error {
+	return t.DB.Transaction(func(tx *gorm.DB) error {
+		return fn(NewMusicRepository(tx), NewRevisionRepository(tx))
+	})
+}
+
+// InMemorySongTransaction is the SongTransactor over the in-memory stores. It
+// is not isolated from concurrent writers, but when fn fails it puts back the
+// songs and revisions of the in-memory stores as they were before.
+type InMemorySongTransaction struct {
+	Songs     MusicStore
+	Revisions RevisionStore
+}
+
+func NewInMemorySongTransaction(songs MusicStore, revisions RevisionStore) *InMemorySongTransaction {
+	return &InMemorySongTransaction{Songs: songs, Revisions: revisions}
+}
+
+// checkpointer is implemented by the in-memory stores; the returned function
+// restores the state they had when checkpoint was called.
+type checkpointer interface {
+	checkpoint() func()
+}
+
+func (t *InMemorySongTransaction) InTransaction(fn func(songs MusicStore, revisions RevisionStore) error) error {
+	var rollbacks []func()
+	for _, store := range []interface{}{t.Songs, t.Revisions} {
+		if store, ok := store.(checkpointer); ok {
+			rollbacks = append(rollbacks, store.checkpoint())
+		}
+	}
+
+	err := fn(t.Songs, t.Revisions)
+	if err != nil {
+		for _, rollback := range rollbacks {
+			rollback()
+		}
+	}
+	return err
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"gorm.io/gorm"
+)
+
+type UserRepository struct {
+	DB *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) *UserRepository {
+	return &UserRepository{DB: db}
+}
+
+// methods:
+func (repo *UserRepository) AddUser(user *models.User) error {
+	return repo.DB.Create(user).Error
+}
+
+func (repo *UserRepository) GetUserByUsername(username string) (*models.User, error) {
+	var user models.User
+	err := repo.DB.Where("username = ?", username).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
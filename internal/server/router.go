@@ -0,0 +1,107 @@
+package server
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srmbackisdeveloper/test-music-info/internal/handlers"
+	"github.com/srmbackisdeveloper/test-music-info/internal/middleware"
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/services"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+// Handlers groups every handler the HTTP API is built from.
+type Handlers struct {
+	Music    *handlers.MusicHandler
+	Artist   *handlers.ArtistHandler
+	Album    *handlers.AlbumHandler
+	Playlist *handlers.PlaylistHandler
+	Auth     *handlers.AuthHandler
+	APIKey   *handlers.APIKeyHandler
+}
+
+// NewRouter registers all API routes. It is shared by cmd/server and the tests.
+// Everything except login and the swagger UI requires a token or API key:
+// viewers may read and manage their own playlists, editors may also create and
+// change the catalogue, and only admins may delete from it or manage users and
+// keys. API keys need the read, write or admin scope for the same routes.
+// Every route but swagger is rate limited per client. No proxy is trusted, so
+// X-Forwarded-For cannot pick the IP anonymous clients are limited by; see
+// SetTrustedProxies.
+func NewRouter(h Handlers, authService services.AuthServicer, apiKeyService services.APIKeyServicer, rateLimiter services.RateLimitServicer) *gin.Engine {
+	router := gin.Default()
+	_ = router.SetTrustedProxies(nil)
+
+	router.POST("/auth/login", middleware.RateLimit(rateLimiter), h.Auth.Login)
+	// swagger
+	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	api := router.Group("/", middleware.RequireAuth(authService, apiKeyService), middleware.RateLimit(rateLimiter))
+	viewer := api.Group("/", middleware.RequireRole(models.RoleViewer))
+	editor := api.Group("/", middleware.RequireRole(models.RoleEditor))
+	admin := api.Group("/", middleware.RequireRole(models.RoleAdmin))
+
+	viewer.GET("/info", h.Music.GetSong)
+	editor.POST("/music", h.Music.AddSong)
+	editor.POST("/music/import", h.Music.ImportSongs)
+	viewer.GET("/music", h.Music.ListSongs)
+	viewer.GET("/music/export", h.Music.ExportSongs)
+	editor.PUT("/music", h.Music.UpsertSong)
+	viewer.GET("/music/:id", h.Music.GetSongByID)
+	editor.PUT("/music/:id", h.Music.UpdateSong)
+	editor.PATCH("/music/:id", h.Music.PatchSong)
+	admin.DELETE("/music/:id", h.Music.DeleteSong)
+	editor.GET("/music/trash", h.Music.ListTrash)
+	editor.POST("/music/:id/restore", h.Music.RestoreSong)
+	admin.DELETE("/music/trash", h.Music.PurgeTrash)
+	viewer.GET("/music/:id/revisions", h.Music.ListRevisions)
+	viewer.GET("/music/:id/revisions/diff", h.Music.DiffRevisions)
+	editor.POST("/music/:id/revisions/:rev/restore", h.Music.RestoreRevision)
+	viewer.GET("/search", h.Music.SearchSongs)
+
+	editor.POST("/artists", h.Artist.AddArtist)
+	viewer.GET("/artists", h.Artist.ListArtists)
+	viewer.GET("/artists/:id", h.Artist.GetArtist)
+	editor.PUT("/artists/:id", h.Artist.UpdateArtist)
+	admin.DELETE("/artists/:id", h.Artist.DeleteArtist)
+
+	editor.POST("/albums", h.Album.AddAlbum)
+	viewer.GET("/albums", h.Album.ListAlbums)
+	viewer.GET("/albums/:id", h.Album.GetAlbum)
+	editor.PUT("/albums/:id/tracks", h.Album.SetTracks)
+
+	// playlist ownership is checked by the handler
+	viewer.POST("/playlists", h.Playlist.AddPlaylist)
+	viewer.GET("/playlists", h.Playlist.ListPlaylists)
+	viewer.GET("/playlists/:id", h.Playlist.GetPlaylist)
+	viewer.POST("/playlists/:id/entries", h.Playlist.AddEntry)
+	viewer.PUT("/playlists/:id/entries/:entryId", h.Playlist.MoveEntry)
+	viewer.DELETE("/playlists/:id/entries/:entryId", h.Playlist.RemoveEntry)
+
+	// show lyrics
+	viewer.GET("/lyrics/:id", h.Music.GetLyrics)
+	viewer.GET("/lyrics/:id/line", h.Music.GetActiveLyricLine)
+	editor.PUT("/lyrics/:id", h.Music.UploadLyrics)
+
+	admin.POST("/users", h.Auth.AddUser)
+	admin.POST("/api-keys", h.APIKey.CreateAPIKey)
+	admin.GET("/api-keys", h.APIKey.ListAPIKeys)
+	admin.DELETE("/api-keys/:id", h.APIKey.RevokeAPIKey)
+
+	return router
+}
+
+// SetTrustedProxies trusts X-Forwarded-For from the comma separated IPs and
+// CIDRs in value, such as the load balancer in front of the API. An empty
+// value trusts none.
+func SetTrustedProxies(router *gin.Engine, value string) error {
+	var proxies []string
+	for _, proxy := range strings.Split(value, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return router.SetTrustedProxies(proxies)
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/repositories"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrArtistNotFound = errors.New("artist not found")
+	ErrInvalidTrack   = errors.New("invalid track")
+)
+
+// AlbumServicer is the behaviour AlbumHandler depends on.
+type AlbumServicer interface {
+	AddAlbum(album *models.Album, songIDs []uint, author string) error
+	GetAlbumByID(id uint) (*models.Album, error)
+	ListAlbums(limit, offset int) ([]models.Album, int, error)
+	SetTracks(albumID uint, songIDs []uint, author string) (*models.Album, error)
+}
+
+var _ AlbumServicer = (*AlbumService)(nil)
+
+type AlbumService struct {
+	AlbumRepo  repositories.AlbumStore
+	ArtistRepo repositories.ArtistStore
+	Songs      MusicServicer
+}
+
+func NewAlbumService(albumRepo repositories.AlbumStore, artistRepo repositories.ArtistStore, songs MusicServicer) *AlbumService {
+	return &AlbumService{
+		AlbumRepo:  albumRepo,
+		ArtistRepo: artistRepo,
+		Songs:      songs,
+	}
+}
+
+func (s *AlbumService) AddAlbum(album *models.Album, songIDs []uint, author string) error {
+	if album.Type == "" {
+		album.Type = models.AlbumTypeAlbum
+	}
+	if album.ArtistID != nil {
+		if _, err := s.ArtistRepo.GetArtistByID(*album.ArtistID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrArtistNotFound
+			}
+			return err
+		}
+	}
+
+	songs, err := s.loadTrackSongs(songIDs)
+	if err != nil {
+		return err
+	}
+
+	album.Tracks = make([]models.AlbumTrack, len(songIDs))
+	for i, songID := range songIDs {
+		album.Tracks[i] = models.AlbumTrack{SongID: songID, Position: i + 1}
+	}
+	if err := s.AlbumRepo.AddAlbum(album); err != nil {
+		return err
+	}
+
+	return s.defaultReleaseDates(album, songs, author)
+}
+
+func (s *AlbumService) GetAlbumByID(id uint) (*models.Album, error) {
+	return s.AlbumRepo.GetAlbumByID(id)
+}
+
+func (s *AlbumService) ListAlbums(limit, offset int) ([]models.Album, int, error) {
+	albums, err := s.AlbumRepo.ListAlbums(limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalAlbums, err := s.AlbumRepo.CountAlbums()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return albums, totalAlbums, nil
+}
+
+// SetTracks replaces the album's tracklist with songIDs in the given order,
+// which covers reordering as well as adding and removing tracks.
+func (s *AlbumService) SetTracks(albumID uint, songIDs []uint, author string) (*models.Album, error) {
+	album, err := s.AlbumRepo.GetAlbumByID(albumID)
+	if err != nil {
+		return nil, err
+	}
+
+	songs, err := s.loadTrackSongs(songIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.AlbumRepo.ReplaceTracks(albumID, songIDs); err != nil {
+		return nil, err
+	}
+	if err := s.defaultReleaseDates(album, songs, author); err != nil {
+		return nil, err
+	}
+
+	return s.AlbumRepo.GetAlbumByID(albumID)
+}
+
+func (s *AlbumService) loadTrackSongs(songIDs []uint) ([]*models.Music, error) {
+	seen := make(map[uint]bool, len(songIDs))
+	songs := make([]*models.Music, 0, len(songIDs))
+	for _, songID := range songIDs {
+		if seen[songID] {
+			return nil, fmt.Errorf("%w: song %d is listed twice", ErrInvalidTrack, songID)
+		}
+		seen[songID] = true
+
+		song, err := s.Songs.GetSongByID(songID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("%w: song %d does not exist", ErrInvalidTrack, songID)
+			}
+			return nil, err
+		}
+		songs = append(songs, song)
+	}
+	return songs, nil
+}
+
+// defaultReleaseDates gives tracks without a release date the album's one,
+// recording the change as made by author.
+func (s *AlbumService) defaultReleaseDates(album *models.Album, songs []*models.Music, author string) error {
+	if album.ReleaseDate.IsZero() {
+		return nil
+	}
+
+	for _, song := range songs {
+		if !song.ReleaseDate.IsZero() {
+			continue
+		}
+		song.ReleaseDate = album.ReleaseDate
+		song.Sources.ReleaseDate = models.SourceAlbum
+		if err := s.Songs.UpdateSong(song, author); err != nil {
+			return err
+		}
+	}
+	return nil
+}
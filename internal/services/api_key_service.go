@@ -0,0 +1,136 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/repositories"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidAPIKey = errors.New("invalid, expired or revoked API key")
+	ErrInvalidExpiry = errors.New("expiry must be in the future")
+)
+
+const (
+	apiKeyPrefix = "mlk_"
+	// apiKeyTouchInterval limits how often last-used timestamps are written.
+	apiKeyTouchInterval = time.Minute
+)
+
+// APIKeyServicer is the behaviour APIKeyHandler and the auth middleware depend on.
+type APIKeyServicer interface {
+	CreateAPIKey(name string, scopes []string, expiresAt *time.Time, createdBy string) (*models.APIKey, string, error)
+	ListAPIKeys(limit, offset int) ([]models.APIKey, int, error)
+	RevokeAPIKey(id uint) error
+	Authenticate(rawKey string) (*Claims, error)
+}
+
+var _ APIKeyServicer = (*APIKeyService)(nil)
+
+type APIKeyService struct {
+	APIKeyRepo repositories.APIKeyStore
+	Now        func() time.Time
+}
+
+func NewAPIKeyService(apiKeyRepo repositories.APIKeyStore) *APIKeyService {
+	return &APIKeyService{
+		APIKeyRepo: apiKeyRepo,
+		Now:        time.Now,
+	}
+}
+
+// CreateAPIKey stores a new key and returns it together with the plaintext
+// value, which cannot be recovered later.
+func (s *APIKeyService) CreateAPIKey(name string, scopes []string, expiresAt *time.Time, createdBy string) (*models.APIKey, string, error) {
+	if expiresAt != nil && !expiresAt.After(s.Now()) {
+		return nil, "", ErrInvalidExpiry
+	}
+
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+	rawKey := apiKeyPrefix + hex.EncodeToString(secret)
+
+	key := &models.APIKey{
+		Name:      name,
+		Prefix:    rawKey[:len(apiKeyPrefix)+8],
+		KeyHash:   hashAPIKey(rawKey),
+		Scopes:    models.Scopes(scopes),
+		CreatedBy: createdBy,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.APIKeyRepo.AddAPIKey(key); err != nil {
+		return nil, "", err
+	}
+	return key, rawKey, nil
+}
+
+func (s *APIKeyService) ListAPIKeys(limit, offset int) ([]models.APIKey, int, error) {
+	keys, err := s.APIKeyRepo.ListAPIKeys(limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalKeys, err := s.APIKeyRepo.CountAPIKeys()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return keys, totalKeys, nil
+}
+
+// RevokeAPIKey disables a key. Revoking it again keeps the first revocation time.
+func (s *APIKeyService) RevokeAPIKey(id uint) error {
+	key, err := s.APIKeyRepo.GetAPIKeyByID(id)
+	if err != nil {
+		return err
+	}
+	if key.RevokedAt != nil {
+		return nil
+	}
+	return s.APIKeyRepo.RevokeAPIKey(id, s.Now())
+}
+
+// Authenticate resolves a plaintext key to the claims of its client and
+// records that the key was used.
+func (s *APIKeyService) Authenticate(rawKey string) (*Claims, error) {
+	key, err := s.APIKeyRepo.GetAPIKeyByHash(hashAPIKey(rawKey))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	now := s.Now()
+	if !key.Active(now) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyTouchInterval {
+		if err := s.APIKeyRepo.TouchAPIKey(key.ID, now); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Claims{
+		APIKeyID: key.ID,
+		Scopes:   key.Scopes,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject: "apikey:" + key.Name,
+		},
+	}, nil
+}
+
+func hashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/repositories"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrArtistExists    = errors.New("an artist with this name already exists")
+	ErrArtistHasSongs  = errors.New("artist still has songs")
+	ErrArtistHasAlbums = errors.New("artist still has albums")
+)
+
+// ArtistServicer is the behaviour ArtistHandler depends on.
+type ArtistServicer interface {
+	AddArtist(artist *models.Artist) error
+	GetArtistByID(id uint) (*models.Artist, error)
+	UpdateArtist(artist *models.Artist) error
+	DeleteArtist(id uint) error
+	ListArtists(limit, offset int) ([]models.Artist, int, error)
+}
+
+var _ ArtistServicer = (*ArtistService)(nil)
+
+type ArtistService struct {
+	ArtistRepo repositories.ArtistStore
+	MusicRepo  repositories.MusicStore
+	AlbumRepo  repositories.AlbumStore
+}
+
+func NewArtistService(artistRepo repositories.ArtistStore, musicRepo repositories.MusicStore, albumRepo repositories.AlbumStore) *ArtistService {
+	return &ArtistService{
+		ArtistRepo: artistRepo,
+		MusicRepo:  musicRepo,
+		AlbumRepo:  albumRepo,
+	}
+}
+
+func (s *ArtistService) AddArtist(artist *models.Artist) error {
+	prepareArtist(artist)
+	if err := s.ensureNameAvailable(artist); err != nil {
+		return err
+	}
+
+	return s.ArtistRepo.AddArtist(artist)
+}
+
+func (s *ArtistService) GetArtistByID(id uint) (*models.Artist, error) {
+	return s.ArtistRepo.GetArtistByID(id)
+}
+
+func (s *ArtistService) UpdateArtist(artist *models.Artist) error {
+	prepareArtist(artist)
+	if err := s.ensureNameAvailable(artist); err != nil {
+		return err
+	}
+
+	return s.ArtistRepo.UpdateArtist(artist)
+}
+
+func (s *ArtistService) DeleteArtist(id uint) error {
+	filter := models.SongFilter{ArtistID: &id}
+	songs, err := s.MusicRepo.CountSongs(filter)
+	if err != nil {
+		return err
+	}
+	// trashed songs still reference the artist until they are purged
+	trashed, err := s.MusicRepo.CountDeletedSongs(filter)
+	if err != nil {
+		return err
+	}
+	if songs+trashed > 0 {
+		return ErrArtistHasSongs
+	}
+
+	albums, err := s.AlbumRepo.CountAlbumsByArtist(id)
+	if err != nil {
+		return err
+	}
+	if albums > 0 {
+		return ErrArtistHasAlbums
+	}
+
+	return s.ArtistRepo.DeleteArtist(id)
+}
+
+func (s *ArtistService) ListArtists(limit, offset int) ([]models.Artist, int, error) {
+	artists, err := s.ArtistRepo.ListArtists(limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalArtists, err := s.ArtistRepo.CountArtists()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return artists, totalArtists, nil
+}
+
+func (s *ArtistService) ensureNameAvailable(artist *models.Artist) error {
+	existing, err := s.ArtistRepo.GetArtistByNormalizedName(artist.NormalizedName)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.ID != artist.ID {
+		return ErrArtistExists
+	}
+	return nil
+}
+
+func prepareArtist(artist *models.Artist) {
+	artist.NormalizedName = models.NormalizeArtistName(artist.Name)
+	if artist.SortName == "" {
+		artist.SortName = models.DefaultSortName(artist.Name)
+	}
+}
+
+// findOrCreateArtist returns the artist a free-text group name resolves to,
+// creating it on first use.
+func findOrCreateArtist(store repositories.ArtistStore, group string) (*models.Artist, error) {
+	artist, err := store.GetArtistByNormalizedName(models.NormalizeArtistName(group))
+	if err == nil {
+		return artist, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	artist = &models.Artist{Name: group}
+	prepareArtist(artist)
+	if err := store.AddArtist(artist); err != nil {
+		return nil, err
+	}
+	return artist, nil
+}
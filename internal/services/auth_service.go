@@ -0,0 +1,132 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/repositories"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrInvalidToken       = errors.New("invalid or expired token")
+	ErrUserExists         = errors.New("a user with this username already exists")
+)
+
+// Claims are the JWT claims issued on login. Subject holds the username.
+// Requests authenticated with an API key get Claims too, with APIKeyID and
+// Scopes set instead of Role; those two fields never appear in a token.
+type Claims struct {
+	Role     string        `json:"role"`
+	APIKeyID uint          `json:"-"`
+	Scopes   models.Scopes `json:"-"`
+	jwt.RegisteredClaims
+}
+
+// IsAPIKey reports whether the request was authenticated with an API key.
+func (c *Claims) IsAPIKey() bool {
+	return c.APIKeyID != 0
+}
+
+// AuthServicer is the behaviour AuthHandler and the auth middleware depend on.
+type AuthServicer interface {
+	Login(username, password string) (string, time.Time, error)
+	ParseToken(token string) (*Claims, error)
+	AddUser(username, password, role string) (*models.User, error)
+}
+
+var _ AuthServicer = (*AuthService)(nil)
+
+type AuthService struct {
+	UserRepo   repositories.UserStore
+	SigningKey []byte
+	TokenTTL   time.Duration
+	// PasswordCost is the bcrypt cost for new password hashes.
+	PasswordCost int
+}
+
+func NewAuthService(userRepo repositories.UserStore, signingKey string, tokenTTL time.Duration) *AuthService {
+	return &AuthService{
+		UserRepo:     userRepo,
+		SigningKey:   []byte(signingKey),
+		TokenTTL:     tokenTTL,
+		PasswordCost: bcrypt.DefaultCost,
+	}
+}
+
+// Login checks the password and returns a signed HS256 token with its expiry.
+func (s *AuthService) Login(username, password string) (string, time.Time, error) {
+	user, err := s.UserRepo.GetUserByUsername(username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", time.Time{}, ErrInvalidCredentials
+		}
+		return "", time.Time{}, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return "", time.Time{}, ErrInvalidCredentials
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.TokenTTL)
+	claims := Claims{
+		Role: user.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   user.Username,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.SigningKey)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+func (s *AuthService) ParseToken(token string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		return s.SigningKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	return claims, nil
+}
+
+func (s *AuthService) AddUser(username, password, role string) (*models.User, error) {
+	if _, err := s.UserRepo.GetUserByUsername(username); err == nil {
+		return nil, ErrUserExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), s.PasswordCost)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &models.User{Username: username, PasswordHash: string(hash), Role: role}
+	if err := s.UserRepo.AddUser(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// EnsureUser creates the user unless the username is already taken; used to
+// bootstrap the first admin from configuration.
+func (s *AuthService) EnsureUser(username, password, role string) error {
+	_, err := s.AddUser(username, password, role)
+	if errors.Is(err, ErrUserExists) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+)
+
+type catalogEntry struct {
+	Group string `json:"group"`
+	Title string `json:"song"`
+	types.SongDetail
+}
+
+// CatalogEnricher serves song details from a local JSON file containing an
+// array of {"group", "song", "releaseDate", "text", "link"} objects.
+type CatalogEnricher struct {
+	name    string
+	entries map[string]types.SongDetail
+}
+
+func NewCatalogEnricher(name, path string) (*CatalogEnricher, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []catalogEntry
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]types.SongDetail, len(list))
+	for _, entry := range list {
+		entries[entry.Group+":"+entry.Title] = entry.SongDetail
+	}
+
+	return &CatalogEnricher{name: name, entries: entries}, nil
+}
+
+func (e *CatalogEnricher) Name() string {
+	return e.name
+}
+
+func (e *CatalogEnricher) Enrich(group, title string) (*types.SongDetail, error) {
+	detail, ok := e.entries[group+":"+title]
+	if !ok {
+		return nil, ErrSongDetailNotFound
+	}
+	return &detail, nil
+}
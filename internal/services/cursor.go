@@ -0,0 +1,110 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/repositories"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// songCursorToken is the JSON inside the opaque cursor tokens handed to
+// clients. Sort is the sort order the cursor was issued for and Values the
+// sort values of the song it points at.
+type songCursorToken struct {
+	ID       uint              `json:"id"`
+	Backward bool              `json:"b,omitempty"`
+	Sort     string            `json:"s,omitempty"`
+	Values   []json.RawMessage `json:"v,omitempty"`
+}
+
+func encodeSongCursor(sort []models.SongSort, song models.Music, backward bool) string {
+	token := songCursorToken{ID: song.ID, Backward: backward, Sort: formatSongSort(sort)}
+	for _, key := range sort {
+		value, _ := json.Marshal(key.SortValue(song))
+		token.Values = append(token.Values, value)
+	}
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeSongCursor reads a token issued for the same sort order.
+func decodeSongCursor(token string, sort []models.SongSort) (repositories.SongCursor, error) {
+	if token == "" {
+		return repositories.SongCursor{}, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return repositories.SongCursor{}, ErrInvalidCursor
+	}
+	var decoded songCursorToken
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.ID == 0 {
+		return repositories.SongCursor{}, ErrInvalidCursor
+	}
+	if decoded.Sort != formatSongSort(sort) || len(decoded.Values) != len(sort) {
+		return repositories.SongCursor{}, ErrInvalidCursor
+	}
+
+	cursor := repositories.SongCursor{ID: decoded.ID, Backward: decoded.Backward}
+	for i, key := range sort {
+		var value interface{}
+		if key.Field == models.SortCreatedAt {
+			var createdAt time.Time
+			err = json.Unmarshal(decoded.Values[i], &createdAt)
+			value = createdAt
+		} else {
+			var text string
+			err = json.Unmarshal(decoded.Values[i], &text)
+			value = text
+		}
+		if err != nil {
+			return repositories.SongCursor{}, ErrInvalidCursor
+		}
+		cursor.Values = append(cursor.Values, value)
+	}
+	return cursor, nil
+}
+
+// ListSongsByCursor returns up to limit songs in sort order from the position
+// cursor points at, "" being the first page, together with the cursors of the
+// next and previous pages; those are "" when there is no such page. A cursor
+// only works with the sort order it was issued for.
+func (s *MusicService) ListSongsByCursor(filter models.SongFilter, sort []models.SongSort, cursor string, limit int) ([]models.Music, string, string, error) {
+	position, err := decodeSongCursor(cursor, sort)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	// one extra song tells whether there is a page beyond this one
+	songs, err := s.MusicRepo.ListSongsByCursor(filter, sort, position, limit+1)
+	if err != nil {
+		return nil, "", "", err
+	}
+	more := len(songs) > limit
+	if more && position.Backward {
+		songs = songs[1:]
+	} else if more {
+		songs = songs[:limit]
+	}
+	if len(songs) == 0 {
+		return songs, "", "", nil
+	}
+
+	next := encodeSongCursor(sort, songs[len(songs)-1], false)
+	prev := encodeSongCursor(sort, songs[0], true)
+	if position.Backward && !more {
+		prev = ""
+	}
+	if !position.Backward && !more {
+		next = ""
+	}
+	if !position.Backward && position.ID == 0 {
+		prev = ""
+	}
+	return songs, next, prev, nil
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+)
+
+// Enricher is a single source of song details (an HTTP API, a local catalog, ...).
+type Enricher interface {
+	Name() string
+	Enrich(group, title string) (*types.SongDetail, error)
+}
+
+// EnrichmentResult is the merged output of an EnrichmentChain together with
+// the provider that supplied each field.
+type EnrichmentResult struct {
+	ReleaseDate time.Time
+	Text        string
+	Link        string
+	Sources     models.FieldSources
+}
+
+func (r *EnrichmentResult) complete() bool {
+	return r.Sources.ReleaseDate != "" && r.Sources.Text != "" && r.Sources.Link != ""
+}
+
+// EnrichmentChain asks providers in order and fills each field from the first
+// provider that has a value for it.
+type EnrichmentChain struct {
+	Providers []Enricher
+}
+
+func NewEnrichmentChain(providers ...Enricher) *EnrichmentChain {
+	return &EnrichmentChain{Providers: providers}
+}
+
+func (c *EnrichmentChain) Enrich(group, title string) (*EnrichmentResult, error) {
+	result := &EnrichmentResult{}
+	var lastErr error
+
+	for _, provider := range c.Providers {
+		detail, err := provider.Enrich(group, title)
+		if err != nil {
+			if !errors.Is(err, ErrSongDetailNotFound) {
+				lastErr = fmt.Errorf("%s: %w", provider.Name(), err)
+			}
+			continue
+		}
+
+		if result.Sources.ReleaseDate == "" && detail.ReleaseDate != "" {
+			if releaseDate, err := parseReleaseDate(detail.ReleaseDate); err == nil {
+				result.ReleaseDate = releaseDate
+				result.Sources.ReleaseDate = provider.Name()
+			}
+		}
+		if result.Sources.Text == "" && detail.Text != "" {
+			result.Text = detail.Text
+			result.Sources.Text = provider.Name()
+		}
+		if result.Sources.Link == "" && detail.Link != "" {
+			result.Link = detail.Link
+			result.Sources.Link = provider.Name()
+		}
+
+		if result.complete() {
+			break
+		}
+	}
+
+	if result.Sources == (models.FieldSources{}) {
+		if lastErr != nil {
+			return nil, lastErr
+		}
+		return nil, ErrSongDetailNotFound
+	}
+
+	return result, nil
+}
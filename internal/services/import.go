@@ -0,0 +1,383 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidImport means the upload as a whole could not be read, as opposed
+// to single rows failing validation.
+var ErrInvalidImport = errors.New("invalid import file")
+
+// Import formats.
+const (
+	ImportFormatCSV    = "csv"
+	ImportFormatJSON   = "json"
+	ImportFormatNDJSON = "ndjson"
+)
+
+// Import row outcomes.
+const (
+	ImportCreated = "created"
+	ImportUpdated = "updated"
+	ImportSkipped = "skipped"
+	ImportFailed  = "failed"
+)
+
+// DefaultImportBatchSize is how many songs are written per transaction.
+const DefaultImportBatchSize = 500
+
+// importColumns are the CSV header names, matching the JSON names of
+// models.Music. group and title are required.
+var importColumns = []string{"group", "title", "releaseDate", "text", "link"}
+
+// ImportRow is one song read from an upload. Row is its 1-based position
+// among the records; Err is set when the record itself could not be decoded.
+type ImportRow struct {
+	Row         int    `json:"-"`
+	Group       string `json:"group"`
+	Title       string `json:"title"`
+	ReleaseDate string `json:"releaseDate"`
+	Text        string `json:"text"`
+	Link        string `json:"link"`
+	Err         error  `json:"-"`
+}
+
+// ParseImport reads the songs in r. Records that cannot be decoded are
+// returned with Err set; ErrInvalidImport is returned only when the upload as
+// a whole is unreadable.
+func ParseImport(r io.Reader, format string) ([]ImportRow, error) {
+	switch format {
+	case ImportFormatCSV:
+		return parseCSVImport(r)
+	case ImportFormatJSON:
+		return parseJSONImport(r)
+	case ImportFormatNDJSON:
+		return parseNDJSONImport(r)
+	default:
+		return nil, fmt.Errorf("%w: unknown format %q", ErrInvalidImport, format)
+	}
+}
+
+func parseCSVImport(r io.Reader) ([]ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: missing CSV header: %v", ErrInvalidImport, err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		for _, known := range importColumns {
+			if strings.EqualFold(name, known) {
+				columns[known] = i
+			}
+		}
+	}
+	for _, required := range []string{"group", "title"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: CSV header has no %q column", ErrInvalidImport, required)
+		}
+	}
+
+	rows := []ImportRow{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		row := ImportRow{Row: len(rows) + 1}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			row.Err = err
+			rows = append(rows, row)
+			continue
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return record[i]
+			}
+			return ""
+		}
+		row.Group = field("group")
+		row.Title = field("title")
+		row.ReleaseDate = field("releaseDate")
+		row.Text = field("text")
+		row.Link = field("link")
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseJSONImport(r io.Reader) ([]ImportRow, error) {
+	decoder := json.NewDecoder(r)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, fmt.Errorf("%w: expected a JSON array of songs", ErrInvalidImport)
+	}
+
+	rows := []ImportRow{}
+	for decoder.More() {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+		}
+		rows = append(rows, decodeImportRow(raw, len(rows)+1))
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	return rows, nil
+}
+
+func parseNDJSONImport(r io.Reader) ([]ImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+
+	rows := []ImportRow{}
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		rows = append(rows, decodeImportRow(line, len(rows)+1))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	return rows, nil
+}
+
+func decodeImportRow(raw []byte, number int) ImportRow {
+	var row ImportRow
+	if err := json.Unmarshal(raw, &row); err != nil {
+		return ImportRow{Row: number, Err: err}
+	}
+	row.Row = number
+	return row
+}
+
+// ImportSongs creates the rows that do not match an existing song by group
+// and title and updates the ones that do; empty fields keep the stored value.
+// Rows are written in transactions of ImportBatchSize songs, each recorded as
+// a revision by author. With dryRun nothing is written, but the result still
+// says what would have happened to every row.
+func (s *MusicService) ImportSongs(rows []ImportRow, dryRun bool, author string) (*types.ImportResult, error) {
+	result := &types.ImportResult{DryRun: dryRun, Rows: make([]types.ImportRowResult, 0, len(rows))}
+
+	batchSize := s.ImportBatchSize
+	if batchSize < 1 {
+		batchSize = DefaultImportBatchSize
+	}
+
+	seen := map[string]int{}
+	for start := 0; start < len(rows); start += batchSize {
+		end := start + batchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		plans := make([]importPlan, 0, end-start)
+		for _, row := range rows[start:end] {
+			plan, err := s.planImportRow(row, seen)
+			if err != nil {
+				return nil, err
+			}
+			plans = append(plans, plan)
+		}
+
+		if !dryRun {
+			s.applyImportBatch(plans, author)
+		}
+		for _, plan := range plans {
+			result.Rows = append(result.Rows, plan.result)
+		}
+	}
+
+	for _, row := range result.Rows {
+		switch row.Action {
+		case ImportCreated:
+			result.Created++
+		case ImportUpdated:
+			result.Updated++
+		case ImportSkipped:
+			result.Skipped++
+		case ImportFailed:
+			result.Failed++
+		}
+	}
+	return result, nil
+}
+
+// isWebLink reports whether link is an absolute http or https URL.
+func isWebLink(link string) bool {
+	parsed, err := url.ParseRequestURI(link)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// importPlan is what ImportSongs will do with one row.
+type importPlan struct {
+	result   types.ImportRowResult
+	song     *models.Music
+	previous *models.Music
+}
+
+func (s *MusicService) planImportRow(row ImportRow, seen map[string]int) (importPlan, error) {
+	row.Group = strings.TrimSpace(row.Group)
+	row.Title = strings.TrimSpace(row.Title)
+	plan := importPlan{result: types.ImportRowResult{Row: row.Row, Group: row.Group, Title: row.Title}}
+	fail := func(format string, args ...interface{}) (importPlan, error) {
+		plan.result.Action = ImportFailed
+		plan.result.Error = fmt.Sprintf(format, args...)
+		return plan, nil
+	}
+
+	if row.Err != nil {
+		return fail("could not decode row: %v", row.Err)
+	}
+	if row.Group == "" || row.Title == "" {
+		return fail("'group' and 'title' are required")
+	}
+
+	var releaseDate time.Time
+	if row.ReleaseDate != "" {
+		var err error
+		if releaseDate, err = time.Parse("2006-01-02", row.ReleaseDate); err != nil {
+			return fail("'releaseDate' must look like 2006-01-02")
+		}
+	}
+	if row.Link != "" && !isWebLink(row.Link) {
+		return fail("'link' must be an http or https URL")
+	}
+
+	key := songCacheKey(row.Group, row.Title)
+	if first, ok := seen[key]; ok {
+		return fail("duplicate of row %d", first)
+	}
+	seen[key] = row.Row
+
+	existing, err := s.MusicRepo.GetSong(row.Group, row.Title)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return plan, err
+	}
+
+	song := &models.Music{Group: row.Group, Title: row.Title}
+	if existing != nil {
+		copied := *existing
+		song = &copied
+		plan.previous = existing
+		plan.result.SongID = existing.ID
+	}
+	if !releaseDate.IsZero() && !releaseDate.Equal(song.ReleaseDate) {
+		song.ReleaseDate = releaseDate
+		song.Sources.ReleaseDate = models.SourceImport
+	}
+	if row.Text != "" && row.Text != song.Text {
+		song.Text = row.Text
+		song.Sources.Text = models.SourceImport
+	}
+	if row.Link != "" && row.Link != song.Link {
+		song.Link = row.Link
+		song.Sources.Link = models.SourceImport
+	}
+
+	switch {
+	case existing == nil:
+		plan.result.Action = ImportCreated
+	case len(models.SnapshotOf(existing).Diff(models.SnapshotOf(song))) == 0:
+		plan.result.Action = ImportSkipped
+		return plan, nil
+	default:
+		plan.result.Action = ImportUpdated
+	}
+	plan.song = song
+	return plan, nil
+}
+
+// applyImportBatch writes the songs planned for creation or update and their
+// revisions in one transaction. When the transaction fails, every row of the
+// batch is marked failed and the import goes on with the next batch.
+func (s *MusicService) applyImportBatch(plans []importPlan, author string) {
+	songs := []*models.Music{}
+	for i := range plans {
+		if plans[i].song == nil {
+			continue
+		}
+		if err := s.linkArtist(plans[i].song); err != nil {
+			log.Printf("ImportSongs: Failed to link artist for row %d: %v", plans[i].result.Row, err)
+			plans[i].result.Action = ImportFailed
+			plans[i].result.Error = "failed to link the artist"
+			plans[i].song = nil
+			continue
+		}
+		songs = append(songs, plans[i].song)
+	}
+	if len(songs) == 0 {
+		return
+	}
+
+	err := s.transaction(func(tx *MusicService) error {
+		if err := tx.MusicRepo.SaveSongs(songs); err != nil {
+			return err
+		}
+		for _, plan := range plans {
+			if plan.song == nil {
+				continue
+			}
+			var err error
+			if plan.previous == nil {
+				err = tx.recordRevision(plan.song, models.RevisionCreate, author, models.FieldChanges{})
+			} else {
+				err = tx.recordChanges(plan.previous, plan.song, models.RevisionUpdate, author)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to record revision of song %d: %w", plan.song.ID, err)
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		log.Printf("ImportSongs: Failed to save batch: %v", err)
+	}
+
+	for i := range plans {
+		plan := &plans[i]
+		if plan.song == nil {
+			continue
+		}
+
+		stale := []*models.Music{plan.song}
+		if plan.previous != nil {
+			stale = append(stale, plan.previous)
+		}
+		if err := s.invalidateSongCache(stale...); err != nil {
+			log.Printf("ImportSongs: %v", err)
+		}
+
+		if err != nil {
+			plan.result.Action = ImportFailed
+			plan.result.Error = "failed to save the batch"
+			continue
+		}
+		plan.result.SongID = plan.song.ID
+	}
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+)
+
+var ErrInvalidLRC = errors.New("invalid LRC file")
+
+var (
+	// lrcTimestamp matches [mm:ss], [mm:ss.x], [mm:ss.xx] and [mm:ss.xxx].
+	lrcTimestamp = regexp.MustCompile(`^\[(\d{1,3}):(\d{2})(?:[.:](\d{1,3}))?\]`)
+	// lrcTag matches ID tags such as [ar:Muse] or [offset:+250].
+	lrcTag = regexp.MustCompile(`^\[([a-zA-Z#]+):(.*)\]$`)
+)
+
+// ParseLRC reads LRC lyrics. Every non-blank line needs at least one
+// timestamp, or must be an ID tag; ID tags other than [offset:] are ignored.
+// A line with several timestamps is repeated at each of them, and the result
+// is ordered by time.
+func ParseLRC(lrc string) (models.LyricLines, error) {
+	lines := models.LyricLines{}
+	var offsetMs int64
+
+	for number, raw := range strings.Split(strings.TrimPrefix(lrc, "\ufeff"), "\n") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		var times []int64
+		rest := raw
+		for {
+			match := lrcTimestamp.FindStringSubmatch(rest)
+			if match == nil {
+				break
+			}
+			timeMs, err := lrcTimeMs(match[1], match[2], match[3])
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidLRC, number+1, err)
+			}
+			times = append(times, timeMs)
+			rest = rest[len(match[0]):]
+		}
+
+		if len(times) == 0 {
+			tag := lrcTag.FindStringSubmatch(raw)
+			if tag == nil {
+				return nil, fmt.Errorf("%w: line %d has no timestamp", ErrInvalidLRC, number+1)
+			}
+			if strings.EqualFold(tag[1], "offset") {
+				offset, err := strconv.ParseInt(strings.TrimSpace(tag[2]), 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("%w: line %d: offset must be a number of milliseconds", ErrInvalidLRC, number+1)
+				}
+				offsetMs = offset
+			}
+			continue
+		}
+
+		text := strings.TrimSpace(rest)
+		for _, timeMs := range times {
+			lines = append(lines, models.LyricLine{TimeMs: timeMs, Text: text})
+		}
+	}
+
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%w: no timed lines", ErrInvalidLRC)
+	}
+
+	// A positive offset makes the lyrics appear sooner.
+	for i := range lines {
+		lines[i].TimeMs -= offsetMs
+		if lines[i].TimeMs < 0 {
+			lines[i].TimeMs = 0
+		}
+	}
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].TimeMs < lines[j].TimeMs })
+
+	return lines, nil
+}
+
+func lrcTimeMs(minutes, seconds, fraction string) (int64, error) {
+	m, _ := strconv.ParseInt(minutes, 10, 64)
+	s, _ := strconv.ParseInt(seconds, 10, 64)
+	if s >= 60 {
+		return 0, fmt.Errorf("seconds must be below 60 in [%s:%s]", minutes, seconds)
+	}
+
+	var ms int64
+	if fraction != "" {
+		ms, _ = strconv.ParseInt((fraction + "00")[:3], 10, 64)
+	}
+	return (m*60+s)*1000 + ms, nil
+}
+
+// SetSyncedLyrics replaces the song's synchronized lyrics with the parsed LRC
+// file, recorded as a revision by author. Invalid files return an error
+// wrapping ErrInvalidLRC. A non-zero version must match the stored one, or
+// ErrVersionConflict is returned.
+func (s *MusicService) SetSyncedLyrics(songID uint, version uint, lrc string, author string) (*models.Music, error) {
+	lines, err := ParseLRC(lrc)
+	if err != nil {
+		return nil, err
+	}
+
+	song, err := s.MusicRepo.GetSongByID(songID)
+	if err != nil {
+		return nil, err
+	}
+	if version != 0 && song.Version != version {
+		return nil, ErrVersionConflict
+	}
+
+	song.SyncedLyrics = lines
+	if err := s.UpdateSong(song, author); err != nil {
+		return nil, err
+	}
+
+	return song, nil
+}
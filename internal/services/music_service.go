@@ -10,31 +10,141 @@ import (
 	"github.com/srmbackisdeveloper/test-music-info/internal/types"
 )
 
+// MusicServicer is the behaviour MusicHandler depends on.
+type MusicServicer interface {
+	AddSong(song *models.Music, author string) error
+	GetSong(group, title string) (*models.Music, error)
+	SuggestSongs(group, title string) ([]types.SongSuggestion, error)
+	UpsertSong(group, title string, version uint, fields *models.Music, author string) (*models.Music, bool, error)
+	GetSongByID(id uint) (*models.Music, error)
+	UpdateSong(song *models.Music, author string) error
+	PatchSong(id uint, version uint, format string, patch []byte, author string) (*models.Music, error)
+	DeleteSong(id uint, version uint, author string) error
+	ListSongs(filter models.SongFilter, sort []models.SongSort, limit, offset int) ([]models.Music, int, error)
+	ListSongsByCursor(filter models.SongFilter, sort []models.SongSort, cursor string, limit int) ([]models.Music, string, string, error)
+	ExportSongs(filter models.SongFilter, fn func(song models.Music) error) error
+	SearchSongs(query string, limit, offset int) ([]types.SearchResult, int, error)
+	ListTrash(limit, offset int) ([]models.Music, int, error)
+	RestoreSong(id uint, author string) (*models.Music, error)
+	PurgeTrash() (int, error)
+	ListRevisions(songID uint, limit, offset int) ([]models.SongRevision, int, error)
+	DiffRevisions(songID uint, from, to int) (models.FieldChanges, error)
+	RestoreRevision(songID uint, revision int, version uint, author string) (*models.Music, error)
+	SetSyncedLyrics(songID uint, version uint, lrc string, author string) (*models.Music, error)
+	ImportSongs(rows []ImportRow, dryRun bool, author string) (*types.ImportResult, error)
+}
+
+var _ MusicServicer = (*MusicService)(nil)
+
+// ErrVersionConflict means the song is no longer at the version a write
+// expected: someone else changed it since it was read.
+var ErrVersionConflict = repositories.ErrVersionConflict
+
 type MusicService struct {
-	MusicRepo *repositories.MusicRepository
-	CacheRepo *repositories.CacheRepository
+	MusicRepo repositories.MusicStore
+	ArtistRepo repositories.ArtistStore
+	RevisionRepo repositories.RevisionStore
+	CacheRepo repositories.SongCache
+	Enrichment *EnrichmentChain
 	CacheTTL time.Duration // time to live
+	TrashRetention time.Duration
+	ImportBatchSize int
+	// Transactor, when set, makes every song write and its revisions commit
+	// together.
+	Transactor repositories.SongTransactor
 }
 
 
-func NewMusicService(musicRepo *repositories.MusicRepository, cacheRepo *repositories.CacheRepository, cacheTTL time.Duration) *MusicService {
+func NewMusicService(musicRepo repositories.MusicStore, artistRepo repositories.ArtistStore, revisionRepo repositories.RevisionStore, cacheRepo repositories.SongCache, enrichment *EnrichmentChain, cacheTTL time.Duration) *MusicService {
 	return &MusicService{
         MusicRepo:   musicRepo,
+        ArtistRepo: artistRepo,
+        RevisionRepo: revisionRepo,
         CacheRepo: cacheRepo,
+        Enrichment: enrichment,
         CacheTTL:  cacheTTL,
+        TrashRetention: DefaultTrashRetention,
+        ImportBatchSize: DefaultImportBatchSize,
+    }
+}
+
+// AddSong enriches and stores a new song as revision 1, authored by author.
+// It returns a SongExistsError when the group and title are taken.
+func (s *MusicService) AddSong(song *models.Music, author string) error {
+    if err := s.ensureSongAvailable(song); err != nil {
+        return err
+    }
+
+    if err := s.EnrichSong(song); err != nil {
+        return err
+    }
+
+    if err := s.linkArtist(song); err != nil {
+        return err
+    }
+
+    err := s.transaction(func(tx *MusicService) error {
+        if err := tx.MusicRepo.AddSong(song); err != nil {
+            return err
+        }
+        return tx.recordRevision(song, models.RevisionCreate, author, models.FieldChanges{})
+    })
+    if err != nil {
+        err = s.songConflict(song, err)
+    }
+
+    return s.afterWrite(err, song)
+}
+
+// transaction runs fn with a copy of the service whose song and revision
+// stores share one transaction, so that a song is never stored without its
+// revision. Without a Transactor fn gets the service itself.
+func (s *MusicService) transaction(fn func(tx *MusicService) error) error {
+    if s.Transactor == nil {
+        return fn(s)
+    }
+
+    return s.Transactor.InTransaction(func(songs repositories.MusicStore, revisions repositories.RevisionStore) error {
+        tx := *s
+        tx.MusicRepo, tx.RevisionRepo = songs, revisions
+        return fn(&tx)
+    })
+}
+
+// afterWrite evicts the cache entries of songs whether or not the write
+// succeeded, so a failed write never leaves GET /info serving a stale song,
+// and returns err, or else the cache error.
+func (s *MusicService) afterWrite(err error, songs ...*models.Music) error {
+    cacheErr := s.invalidateSongCache(songs...)
+    if err != nil {
+        return err
     }
+    return cacheErr
 }
 
-func (s *MusicService) AddSong(song *models.Music) error {
-    if err := s.MusicRepo.AddSong(song); err != nil {
+// EnrichSong fills ReleaseDate, Text and Link from the enrichment providers
+// and records which provider supplied each field.
+func (s *MusicService) EnrichSong(song *models.Music) error {
+    if s.Enrichment == nil {
+        return nil
+    }
+
+    result, err := s.Enrichment.Enrich(song.Group, song.Title)
+    if err != nil {
         return err
     }
 
+    song.ReleaseDate = result.ReleaseDate
+    song.Text = result.Text
+    song.Link = result.Link
+    song.Sources = result.Sources
+
     return nil
 }
 
-func (s *MusicService) GetSong(group, title string) (*types.SongDetail, error) {
-    cacheKey := fmt.Sprintf("%s:%s", group, title)
+// GetSong looks a song up by group and title, through the cache.
+func (s *MusicService) GetSong(group, title string) (*models.Music, error) {
+    cacheKey := songCacheKey(group, title)
 
     cachedData, err := s.CacheRepo.GetSongCache(cacheKey)
     if err != nil {
@@ -47,11 +157,7 @@ func (s *MusicService) GetSong(group, title string) (*types.SongDetail, error) {
             return nil, err
         }
 
-        return &types.SongDetail{
-            ReleaseDate: song.ReleaseDate.Format("2006-01-02"),
-            Text:        song.Text,
-            Link:        song.Link,
-        }, nil
+        return &song, nil
     }
 
     // cache miss
@@ -66,29 +172,124 @@ func (s *MusicService) GetSong(group, title string) (*types.SongDetail, error) {
     }
     _ = s.CacheRepo.SetSongCache(cacheKey, string(data), s.CacheTTL)
 
-    return &types.SongDetail{
-        ReleaseDate: song.ReleaseDate.Format("2006-01-02"),
-        Text:        song.Text,
-        Link:        song.Link,
-    }, nil
+    return song, nil
+}
+
+// SongSuggestionLimit is how many songs SuggestSongs returns at most.
+const SongSuggestionLimit = 5
+
+// SuggestSongs lists the songs whose group and title come closest to the
+// given ones, for when GetSong finds nothing.
+func (s *MusicService) SuggestSongs(group, title string) ([]types.SongSuggestion, error) {
+    songs, err := s.MusicRepo.SuggestSongs(group, title, SongSuggestionLimit)
+    if err != nil {
+        return nil, err
+    }
+
+    suggestions := make([]types.SongSuggestion, 0, len(songs))
+    for _, song := range songs {
+        suggestions = append(suggestions, types.SongSuggestion{ID: song.ID, Group: song.Group, Title: song.Title})
+    }
+    return suggestions, nil
 }
 
-func (s *MusicService) UpdateSong(song *models.Music) error {
-    if err := s.MusicRepo.UpdateSong(song); err != nil {
+// UpdateSong saves the song, records the changed fields as a revision by
+// author and evicts the cache entries for both its previous and its new
+// group/title, so a rename never leaves stale keys. Renaming a song to the
+// group and title of another one returns a SongExistsError. The song is only
+// saved if it is still at song.Version, and ErrVersionConflict is returned
+// otherwise; on success song.Version is the new version.
+func (s *MusicService) UpdateSong(song *models.Music, author string) error {
+    return s.saveSong(song, models.RevisionUpdate, author)
+}
+
+func (s *MusicService) saveSong(song *models.Music, action, author string) error {
+    previous, err := s.MusicRepo.GetSongByID(song.ID)
+    if err != nil {
+        return err
+    }
+
+    if err := s.ensureSongAvailable(song); err != nil {
         return err
     }
 
+    if err := s.linkArtist(song); err != nil {
+        return err
+    }
+
+    err = s.transaction(func(tx *MusicService) error {
+        if err := tx.MusicRepo.UpdateSong(song); err != nil {
+            return err
+        }
+        return tx.recordChanges(previous, song, action, author)
+    })
+    if err != nil {
+        err = s.songConflict(song, err)
+    }
+
+    return s.afterWrite(err, previous, song)
+}
+
+// linkArtist points the song at the artist its group name resolves to.
+func (s *MusicService) linkArtist(song *models.Music) error {
+    if s.ArtistRepo == nil {
+        return nil
+    }
+
+    artist, err := findOrCreateArtist(s.ArtistRepo, song.Group)
+    if err != nil {
+        return err
+    }
+
+    song.ArtistID = &artist.ID
     return nil
 }
 
-func (s *MusicService) DeleteSong(id uint) error {
-	return s.MusicRepo.DeleteSong(id)
+// DeleteSong moves the song to the trash, which moves it to the next version,
+// and records the deletion as a revision by author. A non-zero version must
+// match the stored one, and the song must not change while it is deleted, or
+// ErrVersionConflict is returned.
+func (s *MusicService) DeleteSong(id uint, version uint, author string) error {
+    song, err := s.MusicRepo.GetSongByID(id)
+    if err != nil {
+        return err
+    }
+
+    if version != 0 && song.Version != version {
+        return ErrVersionConflict
+    }
+
+    err = s.transaction(func(tx *MusicService) error {
+        if err := tx.MusicRepo.DeleteSong(id, song.Version); err != nil {
+            return err
+        }
+        song.Version++
+        return tx.recordEvent(song, models.RevisionDelete, author)
+    })
+
+    return s.afterWrite(err, song)
 }
 
+func (s *MusicService) invalidateSongCache(songs ...*models.Music) error {
+    for _, song := range songs {
+        if err := s.CacheRepo.DeleteSongCache(songCacheKey(song.Group, song.Title)); err != nil {
+            return fmt.Errorf("failed to invalidate cache for %q: %w", song.Group+":"+song.Title, err)
+        }
+    }
 
-func (s *MusicService) ListSongs(filter map[string]interface{}, limit, offset int) ([]models.Music, int, error) {
-	// Fetch the filtered and paginated songs
-	songs, err := s.MusicRepo.ListSongs(filter, limit, offset)
+    return nil
+}
+
+// songCacheKey is built from the lookup keys, so every spelling GetSong
+// accepts for a song shares one cache entry.
+func songCacheKey(group, title string) string {
+    return fmt.Sprintf("%s:%s", models.NormalizeLookupKey(group), models.NormalizeLookupKey(title))
+}
+
+
+func (s *MusicService) ListSongs(filter models.SongFilter, sort []models.SongSort, limit, offset int) ([]models.Music, int, error) {
+	// Fetch the filtered, sorted and paginated songs
+	songs, err := s.MusicRepo.ListSongs(filter, sort, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -102,6 +303,12 @@ func (s *MusicService) ListSongs(filter map[string]interface{}, limit, offset in
 	return songs, totalSongs, nil
 }
 
+// ExportSongs calls fn for every song matching filter, ordered by ID, without
+// holding them all in memory.
+func (s *MusicService) ExportSongs(filter models.SongFilter, fn func(song models.Music) error) error {
+	return s.MusicRepo.EachSong(filter, fn)
+}
+
 func (s *MusicService) GetSongByID(id uint) (*models.Music, error) {
 	return s.MusicRepo.GetSongByID(id)
 }
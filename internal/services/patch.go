@@ -0,0 +1,254 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+)
+
+var (
+	// ErrInvalidPatch means the patch document itself is malformed.
+	ErrInvalidPatch = errors.New("invalid patch")
+	// ErrInvalidSong means the patch applied cleanly but left the song invalid.
+	ErrInvalidSong = errors.New("invalid song")
+	// ErrPatchTestFailed means a JSON Patch "test" operation did not match.
+	ErrPatchTestFailed = errors.New("patch test failed")
+)
+
+// Patch formats.
+const (
+	PatchFormatMerge = "merge" // JSON Merge Patch, RFC 7396
+	PatchFormatJSON  = "json"  // JSON Patch, RFC 6902
+)
+
+// patchableFields are the members of the document patches apply to. Release
+// dates are written as 2006-01-02, or "" when unknown.
+var patchableFields = []string{"group", "title", "releaseDate", "text", "link"}
+
+// PatchSong applies a JSON Merge Patch or JSON Patch to the editable fields of
+// a song and saves the result as a revision by author. Only the fields the
+// patch touches change; those are marked as set manually. The patch is applied
+// as a whole or not at all. A non-zero version must match the stored one, or
+// ErrVersionConflict is returned.
+func (s *MusicService) PatchSong(id uint, version uint, format string, patch []byte, author string) (*models.Music, error) {
+	song, err := s.MusicRepo.GetSongByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if version != 0 && song.Version != version {
+		return nil, ErrVersionConflict
+	}
+
+	document := songDocument(song)
+	switch format {
+	case PatchFormatMerge:
+		document, err = applyMergePatch(document, patch)
+	case PatchFormatJSON:
+		document, err = applyJSONPatch(document, patch)
+	default:
+		err = fmt.Errorf("%w: unknown format %q", ErrInvalidPatch, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applySongDocument(song, document); err != nil {
+		return nil, err
+	}
+	if err := s.saveSong(song, models.RevisionUpdate, author); err != nil {
+		return nil, err
+	}
+	return song, nil
+}
+
+func songDocument(song *models.Music) map[string]interface{} {
+	releaseDate := ""
+	if !song.ReleaseDate.IsZero() {
+		releaseDate = song.ReleaseDate.Format("2006-01-02")
+	}
+	return map[string]interface{}{
+		"group":       song.Group,
+		"title":       song.Title,
+		"releaseDate": releaseDate,
+		"text":        song.Text,
+		"link":        song.Link,
+	}
+}
+
+// applySongDocument validates a patched document and copies it onto song.
+// Members a patch removed are cleared.
+func applySongDocument(song *models.Music, document map[string]interface{}) error {
+	fields := map[string]string{}
+	for name, value := range document {
+		if !isPatchableField(name) {
+			return fmt.Errorf("%w: unknown field %q", ErrInvalidSong, name)
+		}
+		if value == nil {
+			continue
+		}
+		text, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%w: '%s' must be a string", ErrInvalidSong, name)
+		}
+		fields[name] = text
+	}
+
+	group, title := strings.TrimSpace(fields["group"]), strings.TrimSpace(fields["title"])
+	if group == "" || title == "" {
+		return fmt.Errorf("%w: 'group' and 'title' must not be empty", ErrInvalidSong)
+	}
+	var releaseDate time.Time
+	if fields["releaseDate"] != "" {
+		var err error
+		if releaseDate, err = time.Parse("2006-01-02", fields["releaseDate"]); err != nil {
+			return fmt.Errorf("%w: 'releaseDate' must look like 2006-01-02", ErrInvalidSong)
+		}
+	}
+	if fields["link"] != "" && !isWebLink(fields["link"]) {
+		return fmt.Errorf("%w: 'link' must be an http or https URL", ErrInvalidSong)
+	}
+
+	song.Group = group
+	song.Title = title
+	applyManualFields(song, &models.Music{ReleaseDate: releaseDate, Text: fields["text"], Link: fields["link"]})
+	return nil
+}
+
+func isPatchableField(name string) bool {
+	for _, field := range patchableFields {
+		if name == field {
+			return true
+		}
+	}
+	return false
+}
+
+// applyMergePatch implements RFC 7396: members set to null are removed, other
+// members replace the target's, and objects are merged recursively.
+func applyMergePatch(document map[string]interface{}, patch []byte) (map[string]interface{}, error) {
+	var decoded interface{}
+	if err := json.Unmarshal(patch, &decoded); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	merged, ok := mergePatch(document, decoded).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: a merge patch for a song must be a JSON object", ErrInvalidPatch)
+	}
+	return merged, nil
+}
+
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = mergePatch(targetObject[name], value)
+	}
+	return targetObject
+}
+
+// jsonPatchOperation is one operation of an RFC 6902 JSON Patch.
+type jsonPatchOperation struct {
+	Op    string           `json:"op"`
+	Path  *string          `json:"path"`
+	From  *string          `json:"from"`
+	Value *json.RawMessage `json:"value"`
+}
+
+// applyJSONPatch implements RFC 6902 on a document whose members are all
+// top-level, so every pointer must name one member, such as "/text".
+func applyJSONPatch(document map[string]interface{}, patch []byte) (map[string]interface{}, error) {
+	var operations []jsonPatchOperation
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, fmt.Errorf("%w: a JSON Patch must be an array of operations: %v", ErrInvalidPatch, err)
+	}
+
+	for i, operation := range operations {
+		fail := func(format string, args ...interface{}) error {
+			return fmt.Errorf("%w: operation %d (%s): %s", ErrInvalidPatch, i, operation.Op, fmt.Sprintf(format, args...))
+		}
+		if operation.Path == nil {
+			return nil, fail("missing 'path'")
+		}
+		name, err := patchMember(*operation.Path)
+		if err != nil {
+			return nil, fail("%v", err)
+		}
+
+		var value interface{}
+		switch operation.Op {
+		case "add", "replace", "test":
+			if operation.Value == nil {
+				return nil, fail("missing 'value'")
+			}
+			if err := json.Unmarshal(*operation.Value, &value); err != nil {
+				return nil, fail("%v", err)
+			}
+		case "move", "copy":
+			if operation.From == nil {
+				return nil, fail("missing 'from'")
+			}
+			from, err := patchMember(*operation.From)
+			if err != nil {
+				return nil, fail("%v", err)
+			}
+			var ok bool
+			if value, ok = document[from]; !ok {
+				return nil, fail("%q does not exist", *operation.From)
+			}
+			if operation.Op == "move" {
+				delete(document, from)
+			}
+		case "remove":
+		default:
+			return nil, fail("unknown operation")
+		}
+
+		_, exists := document[name]
+		switch operation.Op {
+		case "add", "move", "copy":
+			document[name] = value
+		case "replace":
+			if !exists {
+				return nil, fail("%q does not exist", *operation.Path)
+			}
+			document[name] = value
+		case "remove":
+			if !exists {
+				return nil, fail("%q does not exist", *operation.Path)
+			}
+			delete(document, name)
+		case "test":
+			if !exists || !reflect.DeepEqual(document[name], value) {
+				return nil, fmt.Errorf("%w: operation %d: %q does not equal the given value", ErrPatchTestFailed, i, *operation.Path)
+			}
+		}
+	}
+	return document, nil
+}
+
+// patchMember resolves a JSON Pointer (RFC 6901) naming a top-level member.
+func patchMember(pointer string) (string, error) {
+	if !strings.HasPrefix(pointer, "/") || strings.Count(pointer, "/") != 1 {
+		return "", fmt.Errorf("path %q must name one song field, such as \"/text\"", pointer)
+	}
+	name := strings.NewReplacer("~1", "/", "~0", "~").Replace(pointer[1:])
+	if !isPatchableField(name) {
+		return "", fmt.Errorf("unknown field %q, expected one of %s", name, strings.Join(patchableFields, ", "))
+	}
+	return name, nil
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/repositories"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSongNotFound          = errors.New("song not found")
+	ErrPlaylistEntryNotFound = errors.New("playlist entry not found")
+	ErrInvalidPosition       = errors.New("invalid position")
+)
+
+// PlaylistServicer is the behaviour PlaylistHandler depends on.
+type PlaylistServicer interface {
+	AddPlaylist(playlist *models.Playlist) error
+	GetPlaylistByID(id uint) (*models.Playlist, error)
+	ListPlaylists(owner string, includePrivate bool, limit, offset int) ([]models.Playlist, int, error)
+	AddEntry(playlistID, songID uint, position int) (*models.Playlist, error)
+	RemoveEntry(playlistID, entryID uint) (*models.Playlist, error)
+	MoveEntry(playlistID, entryID uint, position int) (*models.Playlist, error)
+}
+
+var _ PlaylistServicer = (*PlaylistService)(nil)
+
+type PlaylistService struct {
+	PlaylistRepo repositories.PlaylistStore
+	MusicRepo    repositories.MusicStore
+}
+
+func NewPlaylistService(playlistRepo repositories.PlaylistStore, musicRepo repositories.MusicStore) *PlaylistService {
+	return &PlaylistService{
+		PlaylistRepo: playlistRepo,
+		MusicRepo:    musicRepo,
+	}
+}
+
+func (s *PlaylistService) AddPlaylist(playlist *models.Playlist) error {
+	if playlist.Visibility == "" {
+		playlist.Visibility = models.VisibilityPrivate
+	}
+	return s.PlaylistRepo.AddPlaylist(playlist)
+}
+
+func (s *PlaylistService) GetPlaylistByID(id uint) (*models.Playlist, error) {
+	return s.PlaylistRepo.GetPlaylistByID(id)
+}
+
+// ListPlaylists returns the playlists of owner (all owners when empty). Private
+// playlists are only included when includePrivate is set.
+func (s *PlaylistService) ListPlaylists(owner string, includePrivate bool, limit, offset int) ([]models.Playlist, int, error) {
+	filter := map[string]interface{}{}
+	if owner != "" {
+		filter["owner"] = owner
+	}
+	if !includePrivate {
+		filter["visibility"] = models.VisibilityPublic
+	}
+
+	playlists, err := s.PlaylistRepo.ListPlaylists(filter, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalPlaylists, err := s.PlaylistRepo.CountPlaylists(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return playlists, totalPlaylists, nil
+}
+
+// AddEntry inserts songID at the 1-based position, or appends it when position is 0.
+func (s *PlaylistService) AddEntry(playlistID, songID uint, position int) (*models.Playlist, error) {
+	playlist, err := s.PlaylistRepo.GetPlaylistByID(playlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.MusicRepo.GetSongByID(songID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSongNotFound
+		}
+		return nil, err
+	}
+
+	entries := playlist.Entries
+	if position == 0 {
+		position = len(entries) + 1
+	}
+	if position < 1 || position > len(entries)+1 {
+		return nil, fmt.Errorf("%w: must be between 1 and %d", ErrInvalidPosition, len(entries)+1)
+	}
+
+	entries = append(entries, models.PlaylistEntry{})
+	copy(entries[position:], entries[position-1:])
+	entries[position-1] = models.PlaylistEntry{SongID: songID}
+
+	return s.saveEntries(playlistID, entries)
+}
+
+func (s *PlaylistService) RemoveEntry(playlistID, entryID uint) (*models.Playlist, error) {
+	playlist, err := s.PlaylistRepo.GetPlaylistByID(playlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	index := entryIndex(playlist.Entries, entryID)
+	if index < 0 {
+		return nil, ErrPlaylistEntryNotFound
+	}
+
+	entries := append(playlist.Entries[:index], playlist.Entries[index+1:]...)
+	return s.saveEntries(playlistID, entries)
+}
+
+// MoveEntry moves an entry to the 1-based position, shifting the entries in between.
+func (s *PlaylistService) MoveEntry(playlistID, entryID uint, position int) (*models.Playlist, error) {
+	playlist, err := s.PlaylistRepo.GetPlaylistByID(playlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := playlist.Entries
+	index := entryIndex(entries, entryID)
+	if index < 0 {
+		return nil, ErrPlaylistEntryNotFound
+	}
+	if position < 1 || position > len(entries) {
+		return nil, fmt.Errorf("%w: must be between 1 and %d", ErrInvalidPosition, len(entries))
+	}
+
+	entry := entries[index]
+	entries = append(entries[:index], entries[index+1:]...)
+	entries = append(entries[:position-1], append([]models.PlaylistEntry{entry}, entries[position-1:]...)...)
+
+	return s.saveEntries(playlistID, entries)
+}
+
+func (s *PlaylistService) saveEntries(playlistID uint, entries []models.PlaylistEntry) (*models.Playlist, error) {
+	for i := range entries {
+		entries[i].Position = i + 1
+		entries[i].Song = nil
+	}
+
+	if err := s.PlaylistRepo.SaveEntries(playlistID, entries); err != nil {
+		return nil, err
+	}
+	return s.PlaylistRepo.GetPlaylistByID(playlistID)
+}
+
+func entryIndex(entries []models.PlaylistEntry, entryID uint) int {
+	for i, entry := range entries {
+		if entry.ID == entryID {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/repositories"
+)
+
+// RateLimitRule allows Limit requests per sliding Window. A zero Limit
+// disables limiting.
+type RateLimitRule struct {
+	Limit  int
+	Window time.Duration
+}
+
+// RateLimitDecision is the rule that applied to a request and its outcome.
+type RateLimitDecision struct {
+	RateLimitRule
+	repositories.RateLimitResult
+}
+
+// RateLimitServicer is the behaviour the rate limit middleware depends on.
+type RateLimitServicer interface {
+	Allow(route, client string) (*RateLimitDecision, error)
+}
+
+var _ RateLimitServicer = (*RateLimiter)(nil)
+
+// RateLimiter applies a rule per route ("GET /info"), falling back to
+// Default, and counts every client of a route separately.
+type RateLimiter struct {
+	Store   repositories.RateLimitStore
+	Default RateLimitRule
+	Routes  map[string]RateLimitRule
+	Now     func() time.Time
+}
+
+func NewRateLimiter(store repositories.RateLimitStore, defaultRule RateLimitRule, routes map[string]RateLimitRule) *RateLimiter {
+	return &RateLimiter{
+		Store:   store,
+		Default: defaultRule,
+		Routes:  routes,
+		Now:     time.Now,
+	}
+}
+
+// Allow records a request of client on route. It returns a nil decision when
+// the route is not limited.
+func (l *RateLimiter) Allow(route, client string) (*RateLimitDecision, error) {
+	rule, ok := l.Routes[route]
+	if !ok {
+		rule = l.Default
+	}
+	if rule.Limit <= 0 {
+		return nil, nil
+	}
+
+	result, err := l.Store.Hit(route+"|"+client, rule.Limit, rule.Window, l.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &RateLimitDecision{RateLimitRule: rule, RateLimitResult: result}, nil
+}
+
+// ParseRateLimitRule parses "<limit>/<window>", e.g. "100/1m". An empty
+// string or "0" disables limiting.
+func ParseRateLimitRule(value string) (RateLimitRule, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "0" {
+		return RateLimitRule{}, nil
+	}
+
+	limitPart, windowPart, found := strings.Cut(value, "/")
+	if !found {
+		return RateLimitRule{}, fmt.Errorf("invalid rate limit %q: expected <limit>/<window>", value)
+	}
+	limit, err := strconv.Atoi(limitPart)
+	if err != nil || limit < 0 {
+		return RateLimitRule{}, fmt.Errorf("invalid rate limit %q: bad limit", value)
+	}
+	window, err := time.ParseDuration(windowPart)
+	if err != nil || window <= 0 {
+		return RateLimitRule{}, fmt.Errorf("invalid rate limit %q: bad window", value)
+	}
+	return RateLimitRule{Limit: limit, Window: window}, nil
+}
+
+// ParseRateLimitRoutes parses a comma separated list of
+// "<METHOD> <path>=<limit>/<window>", e.g. "GET /info=60/1m,GET /search=30/1m".
+// Paths are the router's patterns, such as "/music/:id".
+func ParseRateLimitRoutes(value string) (map[string]RateLimitRule, error) {
+	routes := map[string]RateLimitRule{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		route, ruleValue, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid route rate limit %q: expected <METHOD> <path>=<limit>/<window>", entry)
+		}
+		rule, err := ParseRateLimitRule(ruleValue)
+		if err != nil {
+			return nil, err
+		}
+		routes[strings.Join(strings.Fields(route), " ")] = rule
+	}
+	return routes, nil
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+)
+
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitBreaker opens after Threshold consecutive failures and lets a single
+// trial call through once Cooldown has elapsed.
+type CircuitBreaker struct {
+	Threshold int
+	Cooldown  time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+}
+
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{Threshold: threshold, Cooldown: cooldown}
+}
+
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.Threshold <= 0 || b.failures < b.Threshold {
+		return true
+	}
+	if time.Since(b.openedAt) >= b.Cooldown {
+		// half-open: allow one trial call and re-arm the cooldown
+		b.openedAt = time.Now()
+		return true
+	}
+	return false
+}
+
+func (b *CircuitBreaker) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+}
+
+func (b *CircuitBreaker) RecordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.failures >= b.Threshold {
+		b.openedAt = time.Now()
+	}
+}
+
+// ResilientEnricher wraps a provider with retries (exponential backoff) on
+// transient errors and a circuit breaker.
+type ResilientEnricher struct {
+	Inner      Enricher
+	MaxRetries int
+	Backoff    time.Duration
+	Breaker    *CircuitBreaker
+}
+
+func NewResilientEnricher(inner Enricher, maxRetries int, backoff time.Duration, breaker *CircuitBreaker) *ResilientEnricher {
+	return &ResilientEnricher{
+		Inner:      inner,
+		MaxRetries: maxRetries,
+		Backoff:    backoff,
+		Breaker:    breaker,
+	}
+}
+
+func (e *ResilientEnricher) Name() string {
+	return e.Inner.Name()
+}
+
+func (e *ResilientEnricher) Enrich(group, title string) (*types.SongDetail, error) {
+	if e.Breaker != nil && !e.Breaker.Allow() {
+		return nil, fmt.Errorf("%w: %w", ErrSongDetailUnavailable, ErrCircuitOpen)
+	}
+
+	var err error
+	for attempt := 0; attempt <= e.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(e.Backoff << (attempt - 1))
+		}
+
+		var detail *types.SongDetail
+		detail, err = e.Inner.Enrich(group, title)
+		if err == nil || !isTransient(err) {
+			if e.Breaker != nil {
+				e.Breaker.RecordSuccess()
+			}
+			return detail, err
+		}
+	}
+
+	if e.Breaker != nil {
+		e.Breaker.RecordFailure()
+	}
+	return nil, err
+}
+
+func isTransient(err error) bool {
+	return errors.Is(err, ErrSongDetailUnavailable) || errors.Is(err, ErrSongDetailTimeout)
+}
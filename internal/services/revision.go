@@ -0,0 +1,132 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"gorm.io/gorm"
+)
+
+// ListRevisions lists a song's revisions, newest first.
+func (s *MusicService) ListRevisions(songID uint, limit, offset int) ([]models.SongRevision, int, error) {
+	if _, err := s.MusicRepo.GetSongByID(songID); err != nil {
+		return nil, 0, err
+	}
+
+	revisions, err := s.RevisionRepo.ListRevisions(songID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalRevisions, err := s.RevisionRepo.CountRevisions(songID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return revisions, totalRevisions, nil
+}
+
+// DiffRevisions lists the fields that differ between two revisions of a song,
+// with from as the before side. It returns gorm.ErrRecordNotFound when the
+// song or either revision does not exist.
+func (s *MusicService) DiffRevisions(songID uint, from, to int) (models.FieldChanges, error) {
+	if _, err := s.MusicRepo.GetSongByID(songID); err != nil {
+		return nil, err
+	}
+
+	fromRevision, err := s.RevisionRepo.GetRevision(songID, from)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := s.RevisionRepo.GetRevision(songID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return fromRevision.Snapshot.Diff(toRevision.Snapshot), nil
+}
+
+// RestoreRevision rolls the song back to the state it had at revision. The
+// rollback is itself recorded as a new revision, so it can be undone too. A
+// non-zero version must match the stored one, or ErrVersionConflict is
+// returned.
+func (s *MusicService) RestoreRevision(songID uint, revision int, version uint, author string) (*models.Music, error) {
+	song, err := s.MusicRepo.GetSongByID(songID)
+	if err != nil {
+		return nil, err
+	}
+	if version != 0 && song.Version != version {
+		return nil, ErrVersionConflict
+	}
+
+	target, err := s.RevisionRepo.GetRevision(songID, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	target.Snapshot.ApplyTo(song)
+	if err := s.saveSong(song, models.RevisionRollback, author); err != nil {
+		return nil, err
+	}
+
+	return song, nil
+}
+
+// recordChanges stores the difference between previous and song as a new
+// revision. Songs stored before revisions were recorded first get their
+// previous state as revision 1, so that it can be rolled back to.
+func (s *MusicService) recordChanges(previous, song *models.Music, action, author string) error {
+	if s.RevisionRepo == nil {
+		return nil
+	}
+
+	before, after := models.SnapshotOf(previous), models.SnapshotOf(song)
+	changes := before.Diff(after)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if err := s.ensureHistory(previous); err != nil {
+		return err
+	}
+
+	return s.recordRevision(song, action, author, changes)
+}
+
+// recordEvent stores a revision for an action that leaves the fields of song
+// as they are, such as moving it to the trash and back.
+func (s *MusicService) recordEvent(song *models.Music, action, author string) error {
+	if s.RevisionRepo == nil {
+		return nil
+	}
+
+	if err := s.ensureHistory(song); err != nil {
+		return err
+	}
+
+	return s.recordRevision(song, action, author, models.FieldChanges{})
+}
+
+// ensureHistory records song as revision 1 when it has no revisions yet, for
+// songs stored before revisions were recorded.
+func (s *MusicService) ensureHistory(song *models.Music) error {
+	_, err := s.RevisionRepo.LatestRevision(song.ID)
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return s.recordRevision(song, models.RevisionCreate, "", models.FieldChanges{})
+}
+
+func (s *MusicService) recordRevision(song *models.Music, action, author string, changes models.FieldChanges) error {
+	if s.RevisionRepo == nil {
+		return nil
+	}
+
+	return s.RevisionRepo.AddRevision(&models.SongRevision{
+		SongID:   song.ID,
+		Action:   action,
+		Author:   author,
+		Changes:  changes,
+		Snapshot: models.SnapshotOf(song),
+	})
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"errors"
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/repositories"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+)
+
+var ErrEmptySearchQuery = errors.New("search query has no searchable terms")
+
+const (
+	highlightStart = "<mark>"
+	highlightEnd   = "</mark>"
+)
+
+// SearchSongs runs a ranked prefix search over titles, groups and lyrics and
+// attaches the best matching verse with the matched words highlighted.
+func (s *MusicService) SearchSongs(query string, limit, offset int) ([]types.SearchResult, int, error) {
+	terms := repositories.ParseSearchTerms(query)
+	if len(terms) == 0 {
+		return nil, 0, ErrEmptySearchQuery
+	}
+
+	hits, total, err := s.MusicRepo.SearchSongs(terms, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results := make([]types.SearchResult, 0, len(hits))
+	for _, hit := range hits {
+		results = append(results, types.SearchResult{
+			Song:    hit.Music,
+			Rank:    hit.Rank,
+			Snippet: highlightVerse(hit.Text, terms),
+		})
+	}
+
+	return results, total, nil
+}
+
+// highlightVerse picks the verse matching the most terms and wraps every
+// matching word in <mark> tags. It returns "" when the lyrics do not match.
+func highlightVerse(lyrics string, terms []string) string {
+	bestVerse, bestScore := "", 0
+	for _, verse := range strings.Split(lyrics, "\n\n") {
+		score := 0
+		for _, term := range terms {
+			if verseMatches(verse, term) {
+				score++
+			}
+		}
+		if score > bestScore {
+			bestVerse, bestScore = verse, score
+		}
+	}
+	if bestScore == 0 {
+		return ""
+	}
+
+	return highlightWords(strings.TrimSpace(bestVerse), terms)
+}
+
+func verseMatches(verse, term string) bool {
+	for _, word := range strings.FieldsFunc(verse, isNotWordRune) {
+		if repositories.WordMatchesTerm(word, term) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNotWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// highlightWords returns verse as HTML, escaped so that lyrics can never
+// inject markup, with the words matching terms wrapped in <mark> tags.
+func highlightWords(verse string, terms []string) string {
+	var b strings.Builder
+	runes := []rune(verse)
+	for i := 0; i < len(runes); {
+		if isNotWordRune(runes[i]) {
+			b.WriteString(html.EscapeString(string(runes[i])))
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(runes) && !isNotWordRune(runes[j]) {
+			j++
+		}
+		word := string(runes[i:j])
+		if matchesAnyTerm(word, terms) {
+			b.WriteString(highlightStart + html.EscapeString(word) + highlightEnd)
+		} else {
+			b.WriteString(html.EscapeString(word))
+		}
+		i = j
+	}
+	return b.String()
+}
+
+func matchesAnyTerm(word string, terms []string) bool {
+	for _, term := range terms {
+		if repositories.WordMatchesTerm(word, term) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+)
+
+var (
+	ErrSongDetailNotFound    = errors.New("song details not found in upstream API")
+	ErrSongDetailTimeout     = errors.New("song details API timed out")
+	ErrSongDetailUnavailable = errors.New("song details API is unavailable")
+	ErrSongDetailBadResponse = errors.New("song details API returned an invalid response")
+)
+
+// SongDetailClient talks to the external song details API (GET /info?group=&song=).
+type SongDetailClient struct {
+	ProviderName string
+	BaseURL      string
+	HTTPClient   *http.Client
+}
+
+func NewSongDetailClient(name, baseURL string, timeout time.Duration) *SongDetailClient {
+	return &SongDetailClient{
+		ProviderName: name,
+		BaseURL:      strings.TrimRight(baseURL, "/"),
+		HTTPClient:   &http.Client{Timeout: timeout},
+	}
+}
+
+func (c *SongDetailClient) Name() string {
+	return c.ProviderName
+}
+
+func (c *SongDetailClient) Enrich(group, title string) (*types.SongDetail, error) {
+	query := url.Values{}
+	query.Set("group", group)
+	query.Set("song", title)
+
+	resp, err := c.HTTPClient.Get(c.BaseURL + "/info?" + query.Encode())
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return nil, fmt.Errorf("%w: %v", ErrSongDetailTimeout, err)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrSongDetailUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrSongDetailNotFound
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return nil, fmt.Errorf("%w: status %d", ErrSongDetailUnavailable, resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("%w: status %d", ErrSongDetailBadResponse, resp.StatusCode)
+	}
+
+	var detail types.SongDetail
+	if err := json.NewDecoder(resp.Body).Decode(&detail); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSongDetailBadResponse, err)
+	}
+
+	return &detail, nil
+}
+
+// parseReleaseDate accepts both the upstream "16.07.2006" format and ISO dates.
+func parseReleaseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range []string{"02.01.2006", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%w: unsupported release date %q", ErrSongDetailBadResponse, value)
+}
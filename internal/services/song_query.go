@@ -0,0 +1,62 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+)
+
+var ErrInvalidSort = errors.New("invalid sort")
+
+// ParseSongSort reads a sort parameter such as "group,releaseDate:desc": a
+// comma-separated list of models.SongSortFields, each optionally followed by
+// :asc (the default) or :desc. An empty spec keeps the default order by ID.
+func ParseSongSort(spec string) ([]models.SongSort, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+
+	sort := []models.SongSort{}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(spec, ",") {
+		field, direction, _ := strings.Cut(strings.TrimSpace(part), ":")
+		if !isSongSortField(field) {
+			return nil, fmt.Errorf("%w: unknown field %q, expected one of %s", ErrInvalidSort, field, strings.Join(models.SongSortFields, ", "))
+		}
+		if seen[field] {
+			return nil, fmt.Errorf("%w: field %q is listed twice", ErrInvalidSort, field)
+		}
+		seen[field] = true
+
+		key := models.SongSort{Field: field}
+		switch strings.ToLower(direction) {
+		case "", "asc":
+		case "desc":
+			key.Desc = true
+		default:
+			return nil, fmt.Errorf("%w: direction %q, expected asc or desc", ErrInvalidSort, direction)
+		}
+		sort = append(sort, key)
+	}
+	return sort, nil
+}
+
+func isSongSortField(field string) bool {
+	for _, known := range models.SongSortFields {
+		if field == known {
+			return true
+		}
+	}
+	return false
+}
+
+// formatSongSort is the inverse of ParseSongSort.
+func formatSongSort(sort []models.SongSort) string {
+	keys := make([]string, len(sort))
+	for i, key := range sort {
+		keys[i] = key.String()
+	}
+	return strings.Join(keys, ",")
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"gorm.io/gorm"
+)
+
+// DefaultTrashRetention is how long deleted songs stay restorable unless
+// configured otherwise.
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
+// ListTrash lists deleted songs, most recently deleted first.
+func (s *MusicService) ListTrash(limit, offset int) ([]models.Music, int, error) {
+	songs, err := s.MusicRepo.ListDeletedSongs(models.SongFilter{}, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalSongs, err := s.MusicRepo.CountDeletedSongs(models.SongFilter{})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return songs, totalSongs, nil
+}
+
+// RestoreSong moves a song out of the trash, to its next version, and records
+// the restore as a revision by author. It returns gorm.ErrRecordNotFound when
+// the song is not in the trash and ErrSongExists when another song has taken
+// its group and title meanwhile.
+func (s *MusicService) RestoreSong(id uint, author string) (*models.Music, error) {
+	var song *models.Music
+	err := s.transaction(func(tx *MusicService) error {
+		if err := tx.MusicRepo.RestoreSong(id); err != nil {
+			return err
+		}
+
+		restored, err := tx.MusicRepo.GetSongByID(id)
+		if err != nil {
+			return err
+		}
+		song = restored
+		return tx.recordEvent(song, models.RevisionRestore, author)
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrSongExists
+		}
+		return nil, err
+	}
+
+	return song, s.invalidateSongCache(song)
+}
+
+// PurgeTrash permanently deletes songs that have been in the trash for longer
+// than TrashRetention and returns how many were removed.
+func (s *MusicService) PurgeTrash() (int, error) {
+	return s.MusicRepo.PurgeSongs(time.Now().Add(-s.TrashRetention))
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"gorm.io/gorm"
+)
+
+// ErrSongExists means another song already has the same group and title once
+// both are normalized (see models.NormalizeLookupKey).
+var ErrSongExists = errors.New("a song with this group and title already exists")
+
+// SongExistsError is ErrSongExists with the ID of the song already stored.
+type SongExistsError struct {
+	ExistingID uint
+}
+
+func (e *SongExistsError) Error() string {
+	return fmt.Sprintf("%v: song %d", ErrSongExists, e.ExistingID)
+}
+
+func (e *SongExistsError) Is(target error) bool {
+	return target == ErrSongExists
+}
+
+// ensureSongAvailable fails with a SongExistsError when a song other than
+// song has its group and title.
+func (s *MusicService) ensureSongAvailable(song *models.Music) error {
+	existing, err := s.MusicRepo.GetSong(song.Group, song.Title)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.ID != song.ID {
+		return &SongExistsError{ExistingID: existing.ID}
+	}
+	return nil
+}
+
+// songConflict reports the song that made saving song fail on the unique
+// index, for writers that lost a race with ensureSongAvailable.
+func (s *MusicService) songConflict(song *models.Music, err error) error {
+	if !errors.Is(err, gorm.ErrDuplicatedKey) {
+		return err
+	}
+	existing, lookupErr := s.MusicRepo.GetSong(song.Group, song.Title)
+	if lookupErr != nil || existing.ID == song.ID {
+		return ErrSongExists
+	}
+	return &SongExistsError{ExistingID: existing.ID}
+}
+
+// UpsertSong stores the release date, text and link of fields under group and
+// title: the song those match after normalization is updated, keeping its
+// stored group and title, and a new song is created when none does. Changed
+// fields are marked as set manually. The returned flag tells whether the song
+// was created. A non-zero version must match the stored song, which must
+// exist, or ErrVersionConflict is returned.
+func (s *MusicService) UpsertSong(group, title string, version uint, fields *models.Music, author string) (*models.Music, bool, error) {
+	existing, err := s.MusicRepo.GetSong(group, title)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, err
+	}
+	if version != 0 && (existing == nil || existing.Version != version) {
+		return nil, false, ErrVersionConflict
+	}
+
+	if existing == nil {
+		song := &models.Music{Group: group, Title: title}
+		applyManualFields(song, fields)
+		if err := s.linkArtist(song); err != nil {
+			return nil, false, err
+		}
+		err := s.transaction(func(tx *MusicService) error {
+			if err := tx.MusicRepo.AddSong(song); err != nil {
+				return err
+			}
+			return tx.recordRevision(song, models.RevisionCreate, author, models.FieldChanges{})
+		})
+		if err != nil {
+			err = s.songConflict(song, err)
+		}
+		if err := s.afterWrite(err, song); err != nil {
+			return nil, false, err
+		}
+		return song, true, nil
+	}
+
+	applyManualFields(existing, fields)
+	if err := s.saveSong(existing, models.RevisionUpdate, author); err != nil {
+		return nil, false, err
+	}
+	return existing, false, nil
+}
+
+// applyManualFields copies the release date, text and link of fields onto
+// song, marking the ones that change as set manually.
+func applyManualFields(song, fields *models.Music) {
+	if !fields.ReleaseDate.Equal(song.ReleaseDate) {
+		song.ReleaseDate = fields.ReleaseDate
+		song.Sources.ReleaseDate = models.SourceManual
+	}
+	if fields.Text != song.Text {
+		song.Text = fields.Text
+		song.Sources.Text = models.SourceManual
+	}
+	if fields.Link != song.Link {
+		song.Link = fields.Link
+		song.Sources.Link = models.SourceManual
+	}
+}
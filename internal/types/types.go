@@ -1,22 +1,164 @@
 package types
 
-import "github.com/srmbackisdeveloper/test-music-info/internal/models"
+import (
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+)
 
 type AddSongRequest struct {
 	Group string `json:"group" binding:"required"`
 	Title string `json:"song" binding:"required"`
 }
 
+// UpsertSongRequest holds the fields PUT /music stores; group and title come
+// from the query string.
+type UpsertSongRequest struct {
+	ReleaseDate string `json:"releaseDate" binding:"omitempty,datetime=2006-01-02"`
+	Text        string `json:"text"`
+	Link        string `json:"link" binding:"omitempty,url"`
+}
+
+type ArtistRequest struct {
+	Name       string `json:"name" binding:"required"`
+	SortName   string `json:"sortName"`
+	Country    string `json:"country" binding:"omitempty,iso3166_1_alpha2"`
+	FormedYear int    `json:"formedYear" binding:"omitempty,min=1000,max=9999"`
+	Biography  string `json:"biography"`
+}
+
+type AddAlbumRequest struct {
+	Title       string `json:"title" binding:"required"`
+	ArtistID    *uint  `json:"artistId"`
+	ReleaseDate string `json:"releaseDate" binding:"omitempty,datetime=2006-01-02"`
+	Type        string `json:"type" binding:"omitempty,oneof=album single ep compilation"`
+	SongIDs     []uint `json:"songIds"`
+}
+
+type TracklistRequest struct {
+	SongIDs []uint `json:"songIds" binding:"required"`
+}
+
+type AddPlaylistRequest struct {
+	Name       string `json:"name" binding:"required"`
+	Visibility string `json:"visibility" binding:"omitempty,oneof=public private"`
+}
+
+type AddPlaylistEntryRequest struct {
+	SongID   uint `json:"songId" binding:"required"`
+	Position int  `json:"position" binding:"min=0"`
+}
+
+type MovePlaylistEntryRequest struct {
+	Position int `json:"position" binding:"required,min=1"`
+}
+
+type LoginRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type LoginResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type AddUserRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
+	Role     string `json:"role" binding:"required,oneof=viewer editor admin"`
+}
+
+type AddAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1,dive,oneof=read write admin"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+// APIKeyCreatedResponse is the only response that contains the plaintext key.
+type APIKeyCreatedResponse struct {
+	Message string        `json:"message"`
+	Key     string        `json:"key"`
+	APIKey  models.APIKey `json:"apiKey"`
+}
+
 type SongDetail struct {
 	ReleaseDate string `json:"releaseDate"`
 	Text        string `json:"text"`
 	Link        string `json:"link"`
 }
 
+type SyncedLyricsResponse struct {
+	SongID uint              `json:"songId"`
+	Lines  models.LyricLines `json:"lines"`
+}
+
+// ActiveLyricLineResponse is the line being sung at a playback position.
+// Index is -1 and Line is null before the first line starts; Next is null
+// during the last line.
+type ActiveLyricLineResponse struct {
+	SongID     uint              `json:"songId"`
+	PositionMs int64             `json:"positionMs"`
+	Index      int               `json:"index"`
+	Line       *models.LyricLine `json:"line"`
+	Next       *models.LyricLine `json:"next"`
+}
+
 type ErrorResponse struct {
 	Error string `json:"error"`
 }
 
+// SongConflictResponse is the 409 returned when a song with the same
+// normalized group and title already exists.
+type SongConflictResponse struct {
+	Error  string `json:"error"`
+	SongID uint   `json:"songId,omitempty"`
+}
+
+// SongNotFoundResponse is the 404 of GET /info, with the closest matches.
+type SongNotFoundResponse struct {
+	Error       string           `json:"error"`
+	Suggestions []SongSuggestion `json:"suggestions"`
+}
+
+type SongSuggestion struct {
+	ID    uint   `json:"id"`
+	Group string `json:"group"`
+	Title string `json:"title"`
+}
+
+type PurgeTrashResponse struct {
+	Message string `json:"message"`
+	Purged  int    `json:"purged"`
+}
+
+type RevisionDiffResponse struct {
+	SongID  uint                `json:"songId"`
+	From    int                 `json:"from"`
+	To      int                 `json:"to"`
+	Changes models.FieldChanges `json:"changes"`
+}
+
+// ImportResult reports what an import did, or would do in a dry run, with
+// every row of the upload.
+type ImportResult struct {
+	DryRun  bool              `json:"dryRun"`
+	Created int               `json:"created"`
+	Updated int               `json:"updated"`
+	Skipped int               `json:"skipped"`
+	Failed  int               `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
+}
+
+type ImportRowResult struct {
+	Row    int    `json:"row"`
+	Group  string `json:"group,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Action string `json:"action" enums:"created,updated,skipped,failed"`
+	SongID uint   `json:"songId,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
 type MessageResponse struct {
 	Message string `json:"message"`
 }
@@ -38,3 +180,67 @@ type PaginatedSongsResponse struct {
 	TotalSongs int            `json:"totalSongs"`
 	Data       []models.Music `json:"data"`
 }
+
+type PaginatedRevisionsResponse struct {
+	Page           int                   `json:"page"`
+	Limit          int                   `json:"limit"`
+	TotalPages     int                   `json:"totalPages"`
+	TotalRevisions int                   `json:"totalRevisions"`
+	Data           []models.SongRevision `json:"data"`
+}
+
+// CursorSongsResponse is a page of GET /music in cursor mode. NextCursor and
+// PrevCursor are omitted when there is no such page.
+type CursorSongsResponse struct {
+	Limit      int            `json:"limit"`
+	NextCursor string         `json:"nextCursor,omitempty"`
+	PrevCursor string         `json:"prevCursor,omitempty"`
+	Data       []models.Music `json:"data"`
+}
+
+type PaginatedArtistsResponse struct {
+	Page         int             `json:"page"`
+	Limit        int             `json:"limit"`
+	TotalPages   int             `json:"totalPages"`
+	TotalArtists int             `json:"totalArtists"`
+	Data         []models.Artist `json:"data"`
+}
+
+type PaginatedAlbumsResponse struct {
+	Page        int            `json:"page"`
+	Limit       int            `json:"limit"`
+	TotalPages  int            `json:"totalPages"`
+	TotalAlbums int            `json:"totalAlbums"`
+	Data        []models.Album `json:"data"`
+}
+
+type PaginatedPlaylistsResponse struct {
+	Page           int               `json:"page"`
+	Limit          int               `json:"limit"`
+	TotalPages     int               `json:"totalPages"`
+	TotalPlaylists int               `json:"totalPlaylists"`
+	Data           []models.Playlist `json:"data"`
+}
+
+type PaginatedAPIKeysResponse struct {
+	Page         int             `json:"page"`
+	Limit        int             `json:"limit"`
+	TotalPages   int             `json:"totalPages"`
+	TotalAPIKeys int             `json:"totalApiKeys"`
+	Data         []models.APIKey `json:"data"`
+}
+
+type SearchResult struct {
+	Song    models.Music `json:"song"`
+	Rank    float64      `json:"rank"`
+	Snippet string       `json:"snippet,omitempty"`
+}
+
+type PaginatedSearchResponse struct {
+	Query        string         `json:"query"`
+	Page         int            `json:"page"`
+	Limit        int            `json:"limit"`
+	TotalPages   int            `json:"totalPages"`
+	TotalResults int            `json:"totalResults"`
+	Data         []SearchResult `json:"data"`
+}
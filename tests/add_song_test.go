@@ -0,0 +1,172 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/handlers"
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/services"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMusicService struct {
+	mock.Mock
+}
+
+var _ services.MusicServicer = (*MockMusicService)(nil)
+
+func (m *MockMusicService) AddSong(song *models.Music, author string) error {
+	args := m.Called(song, author)
+	return args.Error(0)
+}
+
+func (m *MockMusicService) GetSong(group, title string) (*models.Music, error) {
+	args := m.Called(group, title)
+	song, _ := args.Get(0).(*models.Music)
+	return song, args.Error(1)
+}
+
+func (m *MockMusicService) GetSongByID(id uint) (*models.Music, error) {
+	args := m.Called(id)
+	song, _ := args.Get(0).(*models.Music)
+	return song, args.Error(1)
+}
+
+func (m *MockMusicService) UpdateSong(song *models.Music, author string) error {
+	args := m.Called(song, author)
+	return args.Error(0)
+}
+
+func (m *MockMusicService) SuggestSongs(group, title string) ([]types.SongSuggestion, error) {
+	args := m.Called(group, title)
+	suggestions, _ := args.Get(0).([]types.SongSuggestion)
+	return suggestions, args.Error(1)
+}
+
+func (m *MockMusicService) UpsertSong(group, title string, version uint, fields *models.Music, author string) (*models.Music, bool, error) {
+	args := m.Called(group, title, version, fields, author)
+	song, _ := args.Get(0).(*models.Music)
+	return song, args.Bool(1), args.Error(2)
+}
+
+func (m *MockMusicService) PatchSong(id uint, version uint, format string, patch []byte, author string) (*models.Music, error) {
+	args := m.Called(id, version, format, patch, author)
+	song, _ := args.Get(0).(*models.Music)
+	return song, args.Error(1)
+}
+
+func (m *MockMusicService) DeleteSong(id uint, version uint, author string) error {
+	args := m.Called(id, version, author)
+	return args.Error(0)
+}
+
+func (m *MockMusicService) ListSongs(filter models.SongFilter, sort []models.SongSort, limit, offset int) ([]models.Music, int, error) {
+	args := m.Called(filter, sort, limit, offset)
+	songs, _ := args.Get(0).([]models.Music)
+	return songs, args.Int(1), args.Error(2)
+}
+
+func (m *MockMusicService) ListSongsByCursor(filter models.SongFilter, sort []models.SongSort, cursor string, limit int) ([]models.Music, string, string, error) {
+	args := m.Called(filter, sort, cursor, limit)
+	songs, _ := args.Get(0).([]models.Music)
+	return songs, args.String(1), args.String(2), args.Error(3)
+}
+
+func (m *MockMusicService) ExportSongs(filter models.SongFilter, fn func(song models.Music) error) error {
+	args := m.Called(filter, fn)
+	return args.Error(0)
+}
+
+func (m *MockMusicService) SearchSongs(query string, limit, offset int) ([]types.SearchResult, int, error) {
+	args := m.Called(query, limit, offset)
+	results, _ := args.Get(0).([]types.SearchResult)
+	return results, args.Int(1), args.Error(2)
+}
+
+func (m *MockMusicService) ListTrash(limit, offset int) ([]models.Music, int, error) {
+	args := m.Called(limit, offset)
+	songs, _ := args.Get(0).([]models.Music)
+	return songs, args.Int(1), args.Error(2)
+}
+
+func (m *MockMusicService) RestoreSong(id uint, author string) (*models.Music, error) {
+	args := m.Called(id, author)
+	song, _ := args.Get(0).(*models.Music)
+	return song, args.Error(1)
+}
+
+func (m *MockMusicService) PurgeTrash() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockMusicService) ListRevisions(songID uint, limit, offset int) ([]models.SongRevision, int, error) {
+	args := m.Called(songID, limit, offset)
+	revisions, _ := args.Get(0).([]models.SongRevision)
+	return revisions, args.Int(1), args.Error(2)
+}
+
+func (m *MockMusicService) DiffRevisions(songID uint, from, to int) (models.FieldChanges, error) {
+	args := m.Called(songID, from, to)
+	changes, _ := args.Get(0).(models.FieldChanges)
+	return changes, args.Error(1)
+}
+
+func (m *MockMusicService) RestoreRevision(songID uint, revision int, version uint, author string) (*models.Music, error) {
+	args := m.Called(songID, revision, version, author)
+	song, _ := args.Get(0).(*models.Music)
+	return song, args.Error(1)
+}
+
+func (m *MockMusicService) SetSyncedLyrics(songID uint, version uint, lrc string, author string) (*models.Music, error) {
+	args := m.Called(songID, version, lrc, author)
+	song, _ := args.Get(0).(*models.Music)
+	return song, args.Error(1)
+}
+
+func (m *MockMusicService) ImportSongs(rows []services.ImportRow, dryRun bool, author string) (*types.ImportResult, error) {
+	args := m.Called(rows, dryRun, author)
+	result, _ := args.Get(0).(*types.ImportResult)
+	return result, args.Error(1)
+}
+
+func TestAddSong(t *testing.T) {
+	mockService := new(MockMusicService)
+	handler := handlers.NewMusicHandler(mockService)
+	router := SetupTestRouter()
+	router.POST("/music", handler.AddSong)
+
+	testSong := &models.Music{Group: "Muse", Title: "Supermassive Black Hole"}
+	mockService.On("AddSong", testSong, "").Return(nil)
+
+	w := PerformRequest(router, "POST", "/music", []byte(`{"group": "Muse", "song": "Supermassive Black Hole"}`))
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "Song added successfully", response["message"])
+	mockService.AssertExpectations(t)
+}
+
+func TestAddSongUpstreamErrors(t *testing.T) {
+	cases := map[error]int{
+		services.ErrSongDetailNotFound:    http.StatusNotFound,
+		services.ErrSongDetailUnavailable: http.StatusBadGateway,
+		services.ErrSongDetailTimeout:     http.StatusGatewayTimeout,
+	}
+
+	for serviceErr, status := range cases {
+		mockService := new(MockMusicService)
+		router := SetupTestRouter()
+		router.POST("/music", handlers.NewMusicHandler(mockService).AddSong)
+		mockService.On("AddSong", mock.Anything, mock.Anything).Return(serviceErr)
+
+		w := PerformRequest(router, "POST", "/music", []byte(`{"group": "Muse", "song": "Hysteria"}`))
+		assert.Equal(t, status, w.Code, serviceErr.Error())
+	}
+}
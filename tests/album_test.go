@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlbumTracklist(t *testing.T) {
+	api := setupAPI(t)
+	released := time.Date(2001, 6, 18, 0, 0, 0, 0, time.UTC)
+	songs := seedSongs(t, api.Songs,
+		models.Music{Group: "Muse", Title: "Plug In Baby"},
+		models.Music{Group: "Muse", Title: "New Born", ReleaseDate: released},
+		models.Music{Group: "Muse", Title: "Bliss"},
+	)
+
+	body := fmt.Sprintf(`{"title": "Origin of Symmetry", "releaseDate": "2001-07-17", "type": "album", "songIds": [%d, %d]}`, songs[1].ID, songs[0].ID)
+	w := api.Request("POST", "/albums", []byte(body))
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var created struct {
+		Album models.Album `json:"album"`
+	}
+	decodeJSON(t, w.Body.Bytes(), &created)
+	require.Len(t, created.Album.Tracks, 2)
+	assert.Equal(t, songs[1].ID, created.Album.Tracks[0].SongID)
+	assert.Equal(t, 1, created.Album.Tracks[0].Position)
+
+	defaulted, err := api.Songs.GetSongByID(songs[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "2001-07-17", defaulted.ReleaseDate.Format("2006-01-02"))
+	assert.Equal(t, models.SourceAlbum, defaulted.Sources.ReleaseDate)
+
+	untouched, err := api.Songs.GetSongByID(songs[1].ID)
+	require.NoError(t, err)
+	assert.True(t, released.Equal(untouched.ReleaseDate))
+
+	path := fmt.Sprintf("/albums/%d", created.Album.ID)
+	body = fmt.Sprintf(`{"songIds": [%d, %d, %d]}`, songs[0].ID, songs[2].ID, songs[1].ID)
+	w = api.Request("PUT", path+"/tracks", []byte(body))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = api.Request("GET", path, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var album models.Album
+	decodeJSON(t, w.Body.Bytes(), &album)
+	require.Len(t, album.Tracks, 3)
+	for i, songID := range []uint{songs[0].ID, songs[2].ID, songs[1].ID} {
+		assert.Equal(t, songID, album.Tracks[i].SongID)
+		assert.Equal(t, i+1, album.Tracks[i].Position)
+		require.NotNil(t, album.Tracks[i].Song)
+	}
+	assert.Equal(t, "Bliss", album.Tracks[1].Song.Title)
+}
+
+func TestAlbumValidation(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Bliss"})
+
+	w := api.Request("POST", "/albums", []byte(`{"title": "X", "type": "mixtape"}`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = api.Request("POST", "/albums", []byte(`{"title": "X", "releaseDate": "17.07.2001"}`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = api.Request("POST", "/albums", []byte(`{"title": "X", "artistId": 42}`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = api.Request("POST", "/albums", []byte(`{"title": "X", "songIds": [999]}`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	body := fmt.Sprintf(`{"title": "X", "songIds": [%d, %d]}`, songs[0].ID, songs[0].ID)
+	w = api.Request("POST", "/albums", []byte(body))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = api.Request("PUT", "/albums/999/tracks", []byte(`{"songIds": []}`))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = api.Request("GET", "/albums/abc", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
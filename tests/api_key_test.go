@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createAPIKey(t *testing.T, api *testAPI, body string) types.APIKeyCreatedResponse {
+	w := api.Request("POST", "/api-keys", []byte(body))
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created types.APIKeyCreatedResponse
+	decodeJSON(t, w.Body.Bytes(), &created)
+	return created
+}
+
+func TestAPIKeyScopes(t *testing.T) {
+	api := setupAPI(t)
+	created := createAPIKey(t, api, `{"name": "importer", "scopes": ["read", "write"]}`)
+	assert.NotEmpty(t, created.Key)
+	assert.Equal(t, created.Key[:len(created.APIKey.Prefix)], created.APIKey.Prefix)
+	assert.Equal(t, models.Scopes{"read", "write"}, created.APIKey.Scopes)
+	assert.Equal(t, "admin", created.APIKey.CreatedBy)
+
+	w := PerformAPIKeyRequest(api.Router, created.Key, "POST", "/music", []byte(`{"group": "Muse", "song": "Hysteria"}`))
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w = PerformAPIKeyRequest(api.Router, created.Key, "GET", "/music", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = PerformAPIKeyRequest(api.Router, created.Key, "DELETE", "/music/1", nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	readOnly := createAPIKey(t, api, `{"name": "reporting", "scopes": ["read"]}`)
+	w = PerformAPIKeyRequest(api.Router, readOnly.Key, "POST", "/artists", []byte(`{"name": "Queen"}`))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = PerformAPIKeyRequest(api.Router, "mlk_unknown", "GET", "/music", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAPIKeyLifecycle(t *testing.T) {
+	api := setupAPI(t)
+	created := createAPIKey(t, api, `{"name": "nightly", "scopes": ["read"]}`)
+
+	w := PerformAPIKeyRequest(api.Router, created.Key, "GET", "/music", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = api.Request("GET", "/api-keys", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var page types.PaginatedAPIKeysResponse
+	decodeJSON(t, w.Body.Bytes(), &page)
+	require.Equal(t, 1, page.TotalAPIKeys)
+	assert.NotNil(t, page.Data[0].LastUsedAt)
+	assert.NotContains(t, w.Body.String(), created.Key)
+
+	w = api.Request("DELETE", fmt.Sprintf("/api-keys/%d", created.APIKey.ID), nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = PerformAPIKeyRequest(api.Router, created.Key, "GET", "/music", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = api.Request("DELETE", "/api-keys/999", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAPIKeyExpiry(t *testing.T) {
+	api := setupAPI(t)
+
+	past := time.Now().Add(-time.Hour).Format(time.RFC3339)
+	w := api.Request("POST", "/api-keys", []byte(fmt.Sprintf(`{"name": "old", "scopes": ["read"], "expiresAt": %q}`, past)))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = api.Request("POST", "/api-keys", []byte(`{"name": "bad", "scopes": ["superuser"]}`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	soon := time.Now().Add(time.Hour)
+	created := createAPIKey(t, api, fmt.Sprintf(`{"name": "temp", "scopes": ["read"], "expiresAt": %q}`, soon.Format(time.RFC3339)))
+
+	api.APIKeys.Now = func() time.Time { return soon.Add(time.Minute) }
+	w = PerformAPIKeyRequest(api.Router, created.Key, "GET", "/music", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = api.RequestAs("viewer", "GET", "/api-keys", nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
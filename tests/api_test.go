@@ -0,0 +1,281 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srmbackisdeveloper/test-music-info/internal/handlers"
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/repositories"
+	"github.com/srmbackisdeveloper/test-music-info/internal/server"
+	"github.com/srmbackisdeveloper/test-music-info/internal/services"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type testAPI struct {
+	Router    *gin.Engine
+	Handler   *handlers.MusicHandler
+	Songs     *repositories.InMemoryMusicRepository
+	Music     *services.MusicService
+	Artists   *repositories.InMemoryArtistRepository
+	Albums    *repositories.InMemoryAlbumRepository
+	Playlists *repositories.InMemoryPlaylistRepository
+	Revisions *repositories.InMemoryRevisionRepository
+	Auth      *services.AuthService
+	APIKeys   *services.APIKeyService
+	Limiter   *services.RateLimiter
+	tokens    map[string]string
+}
+
+// testUsers are created by setupAPI; each one's password is its name + "-password".
+var testUsers = map[string]string{
+	"admin":  models.RoleAdmin,
+	"editor": models.RoleEditor,
+	"viewer": models.RoleViewer,
+	"alice":  models.RoleViewer,
+	"bob":    models.RoleViewer,
+}
+
+// setupAPI builds the same router as cmd/server on top of in-memory stores
+// and a fake song details API.
+func setupAPI(t *testing.T) *testAPI {
+	gin.SetMode(gin.TestMode)
+
+	detailServer := NewFakeSongDetailServer(map[string]types.SongDetail{
+		"Muse:Hysteria": {
+			ReleaseDate: "01.12.2003",
+			Text:        "It's bugging me\n\nGrating me\n\nAnd twisting me around",
+			Link:        "https://example.com/hysteria",
+		},
+		"The Beatles:Hey Jude": {ReleaseDate: "26.08.1968", Text: "Hey Jude, don't make it bad"},
+		"Beatles:Let It Be":    {ReleaseDate: "06.03.1970", Text: "When I find myself in times of trouble"},
+	})
+	t.Cleanup(detailServer.Close)
+
+	api := &testAPI{
+		Songs:     repositories.NewInMemoryMusicRepository(),
+		Artists:   repositories.NewInMemoryArtistRepository(),
+		Revisions: repositories.NewInMemoryRevisionRepository(),
+	}
+	api.Albums = repositories.NewInMemoryAlbumRepository(api.Songs)
+	api.Playlists = repositories.NewInMemoryPlaylistRepository(api.Songs)
+	enrichment := services.NewEnrichmentChain(services.NewSongDetailClient("primary", detailServer.URL, time.Second))
+	api.Music = services.NewMusicService(api.Songs, api.Artists, api.Revisions, repositories.NewInMemoryCacheRepository(), enrichment, time.Hour)
+	api.Music.Transactor = repositories.NewInMemorySongTransaction(api.Songs, api.Revisions)
+	artistService := services.NewArtistService(api.Artists, api.Songs, api.Albums)
+	albumService := services.NewAlbumService(api.Albums, api.Artists, api.Music)
+	playlistService := services.NewPlaylistService(api.Playlists, api.Songs)
+	api.Auth = services.NewAuthService(repositories.NewInMemoryUserRepository(), "test-signing-key", time.Hour)
+	api.Auth.PasswordCost = bcrypt.MinCost
+	api.APIKeys = services.NewAPIKeyService(repositories.NewInMemoryAPIKeyRepository())
+	api.Limiter = services.NewRateLimiter(repositories.NewInMemoryRateLimitRepository(), services.RateLimitRule{Limit: 1000, Window: time.Minute}, map[string]services.RateLimitRule{})
+
+	api.tokens = map[string]string{}
+	for username, role := range testUsers {
+		_, err := api.Auth.AddUser(username, username+"-password", role)
+		require.NoError(t, err)
+		api.tokens[username], _, err = api.Auth.Login(username, username+"-password")
+		require.NoError(t, err)
+	}
+
+	api.Handler = handlers.NewMusicHandler(api.Music)
+	api.Router = server.NewRouter(server.Handlers{
+		Music:    api.Handler,
+		Artist:   handlers.NewArtistHandler(artistService),
+		Album:    handlers.NewAlbumHandler(albumService),
+		Playlist: handlers.NewPlaylistHandler(playlistService),
+		Auth:     handlers.NewAuthHandler(api.Auth),
+		APIKey:   handlers.NewAPIKeyHandler(api.APIKeys),
+	}, api.Auth, api.APIKeys, api.Limiter)
+	return api
+}
+
+// Request performs an authenticated request as the admin user.
+func (api *testAPI) Request(method, path string, body []byte) *httptest.ResponseRecorder {
+	return api.RequestAs("admin", method, path, body)
+}
+
+// RequestAs performs a request with the token of one of the testUsers.
+func (api *testAPI) RequestAs(username, method, path string, body []byte) *httptest.ResponseRecorder {
+	return PerformAuthRequest(api.Router, api.tokens[username], method, path, body)
+}
+
+// RequestWithHeaders performs a request as the admin user with extra headers.
+func (api *testAPI) RequestWithHeaders(method, path string, body []byte, headers map[string]string) *httptest.ResponseRecorder {
+	return PerformAuthRequestWithHeaders(api.Router, api.tokens["admin"], method, path, body, headers)
+}
+
+func seedSongs(t *testing.T, repo *repositories.InMemoryMusicRepository, songs ...models.Music) []models.Music {
+	for i := range songs {
+		require.NoError(t, repo.AddSong(&songs[i]))
+	}
+	return songs
+}
+
+func decodeJSON(t *testing.T, body []byte, v interface{}) {
+	require.NoError(t, json.Unmarshal(body, v))
+}
+
+func TestAddSongAndGetInfo(t *testing.T) {
+	api := setupAPI(t)
+
+	w := api.Request("POST", "/music", []byte(`{"group": "Muse", "song": "Hysteria"}`))
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var created struct {
+		Song models.Music `json:"song"`
+	}
+	decodeJSON(t, w.Body.Bytes(), &created)
+	assert.NotZero(t, created.Song.ID)
+	assert.Equal(t, "https://example.com/hysteria", created.Song.Link)
+
+	w = api.Request("GET", "/info?group=Muse&song=Hysteria", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var detail types.SongDetail
+	decodeJSON(t, w.Body.Bytes(), &detail)
+	assert.Equal(t, types.SongDetail{
+		ReleaseDate: "2003-12-01",
+		Text:        "It's bugging me\n\nGrating me\n\nAnd twisting me around",
+		Link:        "https://example.com/hysteria",
+	}, detail)
+}
+
+func TestAddSongErrors(t *testing.T) {
+	api := setupAPI(t)
+
+	w := api.Request("POST", "/music", []byte(`{"group": "Muse"}`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = api.Request("POST", "/music", []byte(`not json`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = api.Request("POST", "/music", []byte(`{"group": "Muse", "song": "Unknown"}`))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetInfoErrors(t *testing.T) {
+	api := setupAPI(t)
+
+	w := api.Request("GET", "/info?group=Muse", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = api.Request("GET", "/info?group=Muse&song=Hysteria", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestListSongsPaginationAndFilters(t *testing.T) {
+	api := setupAPI(t)
+	seedSongs(t, api.Songs,
+		models.Music{Group: "Muse", Title: "Hysteria"},
+		models.Music{Group: "Muse", Title: "Uprising"},
+		models.Music{Group: "Queen", Title: "Bohemian Rhapsody"},
+		models.Music{Group: "Queen", Title: "We Will Rock You"},
+		models.Music{Group: "Radiohead", Title: "Creep"},
+	)
+
+	w := api.Request("GET", "/music?page=2&limit=2", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var page types.PaginatedSongsResponse
+	decodeJSON(t, w.Body.Bytes(), &page)
+	assert.Equal(t, 2, page.Page)
+	assert.Equal(t, 2, page.Limit)
+	assert.Equal(t, 3, page.TotalPages)
+	assert.Equal(t, 5, page.TotalSongs)
+	require.Len(t, page.Data, 2)
+	assert.Equal(t, "Bohemian Rhapsody", page.Data[0].Title)
+
+	w = api.Request("GET", "/music?group=Muse", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &page)
+	assert.Equal(t, 2, page.TotalSongs)
+	assert.Equal(t, 10, page.Limit)
+
+	w = api.Request("GET", "/music?group=Queen&title=Creep", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &page)
+	assert.Equal(t, 0, page.TotalSongs)
+	assert.Empty(t, page.Data)
+
+	w = api.Request("GET", "/music?page=-1&limit=abc", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &page)
+	assert.Equal(t, 1, page.Page)
+	assert.Equal(t, 10, page.Limit)
+}
+
+func TestUpdateSong(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria", Text: "old"})
+
+	body := []byte(`{"group": "Muse", "title": "Hysteria", "text": "new", "link": "https://example.com/new"}`)
+	w := api.Request("PUT", fmt.Sprintf("/music/%d", songs[0].ID), body)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	updated, err := api.Songs.GetSongByID(songs[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "new", updated.Text)
+	assert.Equal(t, models.SourceManual, updated.Sources.Text)
+
+	w = api.Request("PUT", "/music/abc", body)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = api.Request("PUT", "/music/0", body)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = api.Request("PUT", "/music/999", body)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = api.Request("PUT", fmt.Sprintf("/music/%d", songs[0].ID), []byte(`{"group": 1}`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestDeleteSong(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria"})
+	path := fmt.Sprintf("/music/%d", songs[0].ID)
+
+	w := api.Request("DELETE", path, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = api.Request("DELETE", path, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = api.Request("DELETE", "/music/abc", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetLyrics(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria", Text: "one\n\ntwo\n\nthree"})
+	path := fmt.Sprintf("/lyrics/%d", songs[0].ID)
+
+	w := api.Request("GET", path+"?page=2&limit=2", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var verses types.PaginatedVersesResponse
+	decodeJSON(t, w.Body.Bytes(), &verses)
+	assert.Equal(t, 3, verses.TotalVerses)
+	assert.Equal(t, 2, verses.TotalPages)
+	assert.Equal(t, []string{"three"}, verses.Data)
+
+	w = api.Request("GET", path+"?page=5", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &verses)
+	assert.Empty(t, verses.Data)
+
+	w = api.Request("GET", "/lyrics/abc", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = api.Request("GET", "/lyrics/999", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
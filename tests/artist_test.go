@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtistCRUD(t *testing.T) {
+	api := setupAPI(t)
+
+	w := api.Request("POST", "/artists", []byte(`{"name": "The Beatles", "country": "GB", "formedYear": 1960}`))
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var created struct {
+		Artist models.Artist `json:"artist"`
+	}
+	decodeJSON(t, w.Body.Bytes(), &created)
+	assert.Equal(t, "Beatles, The", created.Artist.SortName)
+	path := fmt.Sprintf("/artists/%d", created.Artist.ID)
+
+	w = api.Request("POST", "/artists", []byte(`{"name": "beatles"}`))
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = api.Request("POST", "/artists", []byte(`{"name": "Muse", "country": "England"}`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = api.Request("PUT", path, []byte(`{"name": "The Beatles", "biography": "Liverpool"}`))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = api.Request("GET", path, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var artist models.Artist
+	decodeJSON(t, w.Body.Bytes(), &artist)
+	assert.Equal(t, "Liverpool", artist.Biography)
+
+	w = api.Request("GET", "/artists", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var list types.PaginatedArtistsResponse
+	decodeJSON(t, w.Body.Bytes(), &list)
+	assert.Equal(t, 1, list.TotalArtists)
+
+	w = api.Request("DELETE", path, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = api.Request("GET", path, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = api.Request("GET", "/artists/abc", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSongsAreLinkedToArtists(t *testing.T) {
+	api := setupAPI(t)
+
+	w := api.Request("POST", "/music", []byte(`{"group": "The Beatles", "song": "Hey Jude"}`))
+	require.Equal(t, http.StatusCreated, w.Code)
+	w = api.Request("POST", "/music", []byte(`{"group": "Beatles", "song": "Let It Be"}`))
+	require.Equal(t, http.StatusCreated, w.Code)
+	w = api.Request("POST", "/music", []byte(`{"group": "Muse", "song": "Hysteria"}`))
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	beatles, err := api.Artists.GetArtistByNormalizedName("beatles")
+	require.NoError(t, err)
+
+	w = api.Request("GET", fmt.Sprintf("/music?artistId=%d", beatles.ID), nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var page types.PaginatedSongsResponse
+	decodeJSON(t, w.Body.Bytes(), &page)
+	assert.Equal(t, 2, page.TotalSongs)
+
+	w = api.Request("DELETE", fmt.Sprintf("/artists/%d", beatles.ID), nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = api.Request("GET", "/music?artistId=abc", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestDeleteArtistWithAlbums(t *testing.T) {
+	api := setupAPI(t)
+
+	w := api.Request("POST", "/artists", []byte(`{"name": "Muse"}`))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		Artist models.Artist `json:"artist"`
+	}
+	decodeJSON(t, w.Body.Bytes(), &created)
+	path := fmt.Sprintf("/artists/%d", created.Artist.ID)
+
+	w = api.Request("POST", "/albums", []byte(fmt.Sprintf(`{"title": "Absolution", "artistId": %d}`, created.Artist.ID)))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = api.Request("DELETE", path, nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "Artist still has albums")
+
+	w = api.Request("GET", path, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
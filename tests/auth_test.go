@@ -0,0 +1,79 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogin(t *testing.T) {
+	api := setupAPI(t)
+
+	w := PerformRequest(api.Router, "POST", "/auth/login", []byte(`{"username": "editor", "password": "editor-password"}`))
+	require.Equal(t, http.StatusOK, w.Code)
+	var login types.LoginResponse
+	decodeJSON(t, w.Body.Bytes(), &login)
+	assert.NotEmpty(t, login.Token)
+	assert.False(t, login.ExpiresAt.IsZero())
+
+	w = PerformAuthRequest(api.Router, login.Token, "GET", "/music", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = PerformRequest(api.Router, "POST", "/auth/login", []byte(`{"username": "editor", "password": "wrong"}`))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = PerformRequest(api.Router, "POST", "/auth/login", []byte(`{"username": "nobody", "password": "whatever"}`))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAuthenticationRequired(t *testing.T) {
+	api := setupAPI(t)
+
+	w := PerformRequest(api.Router, "GET", "/music", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = PerformAuthRequest(api.Router, "not-a-token", "GET", "/music", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestRoles(t *testing.T) {
+	api := setupAPI(t)
+
+	w := api.RequestAs("viewer", "POST", "/music", []byte(`{"group": "Muse", "song": "Hysteria"}`))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = api.RequestAs("editor", "POST", "/music", []byte(`{"group": "Muse", "song": "Hysteria"}`))
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w = api.RequestAs("viewer", "GET", "/music", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = api.RequestAs("editor", "DELETE", "/music/1", nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = api.RequestAs("admin", "DELETE", "/music/1", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAddUser(t *testing.T) {
+	api := setupAPI(t)
+	body := []byte(`{"username": "carol", "password": "carol-password", "role": "editor"}`)
+
+	w := api.RequestAs("editor", "POST", "/users", body)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = api.Request("POST", "/users", body)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w = api.Request("POST", "/users", body)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = api.Request("POST", "/users", []byte(`{"username": "dave", "password": "short", "role": "editor"}`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = PerformRequest(api.Router, "POST", "/auth/login", []byte(`{"username": "carol", "password": "carol-password"}`))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/handlers"
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/repositories"
+	"github.com/srmbackisdeveloper/test-music-info/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupCacheTestHandler() (*handlers.MusicHandler, *repositories.InMemoryMusicRepository) {
+	musicRepo := repositories.NewInMemoryMusicRepository()
+	cacheRepo := repositories.NewInMemoryCacheRepository()
+
+	service := services.NewMusicService(musicRepo, nil, nil, cacheRepo, nil, time.Hour)
+	return handlers.NewMusicHandler(service), musicRepo
+}
+
+func TestInfoReflectsUpdateImmediately(t *testing.T) {
+	handler, musicRepo := setupCacheTestHandler()
+	router := SetupTestRouter()
+	router.GET("/info", handler.GetSong)
+	router.PUT("/music/:id", handler.UpdateSong)
+
+	group := "Cache Group"
+	song := &models.Music{Group: group, Title: "Before", Text: "old text"}
+	require.NoError(t, musicRepo.AddSong(song))
+
+	w := PerformRequest(router, "GET", "/info?group="+url.QueryEscape(group)+"&song=Before", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "old text")
+
+	body := fmt.Sprintf(`{"group": %q, "title": "After", "text": "new text"}`, group)
+	w = PerformRequest(router, "PUT", fmt.Sprintf("/music/%d", song.ID), []byte(body))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = PerformRequest(router, "GET", "/info?group="+url.QueryEscape(group)+"&song=Before", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = PerformRequest(router, "GET", "/info?group="+url.QueryEscape(group)+"&song=After", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "new text")
+}
+
+func TestInfoReflectsDeleteImmediately(t *testing.T) {
+	handler, musicRepo := setupCacheTestHandler()
+	router := SetupTestRouter()
+	router.GET("/info", handler.GetSong)
+	router.DELETE("/music/:id", handler.DeleteSong)
+
+	group := "Cache Group"
+	song := &models.Music{Group: group, Title: "Doomed", Text: "soon gone"}
+	require.NoError(t, musicRepo.AddSong(song))
+
+	w := PerformRequest(router, "GET", "/info?group="+url.QueryEscape(group)+"&song=Doomed", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = PerformRequest(router, "DELETE", fmt.Sprintf("/music/%d", song.ID), nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = PerformRequest(router, "GET", "/info?group="+url.QueryEscape(group)+"&song=Doomed", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
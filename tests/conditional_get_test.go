@@ -0,0 +1,121 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/handlers"
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionalGetWithETag(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria", Text: "It's bugging me\n\nGrating me"})
+	id := songs[0].ID
+
+	for _, path := range []string{"/info?group=Muse&song=Hysteria", fmt.Sprintf("/music/%d", id), fmt.Sprintf("/lyrics/%d", id)} {
+		w := api.Request("GET", path, nil)
+		require.Equal(t, http.StatusOK, w.Code, path)
+		etag := w.Header().Get("ETag")
+		assert.Equal(t, songETag(id, 1), etag, path)
+		assert.NotEmpty(t, w.Header().Get("Last-Modified"), path)
+		assert.Equal(t, handlers.DefaultCacheControl, w.Header().Get("Cache-Control"), path)
+
+		w = api.RequestWithHeaders("GET", path, nil, map[string]string{"If-None-Match": etag})
+		assert.Equal(t, http.StatusNotModified, w.Code, path)
+		assert.Empty(t, w.Body.String(), path)
+		assert.Equal(t, etag, w.Header().Get("ETag"), path)
+
+		// If-None-Match compares weakly
+		w = api.RequestWithHeaders("GET", path, nil, map[string]string{"If-None-Match": `"7", W/` + songETag(id, 1)})
+		assert.Equal(t, http.StatusNotModified, w.Code, path)
+	}
+
+	w := api.Request("PATCH", fmt.Sprintf("/music/%d", id), []byte(`{"text": "It's bugging me"}`))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	for _, path := range []string{"/info?group=Muse&song=Hysteria", fmt.Sprintf("/music/%d", id), fmt.Sprintf("/lyrics/%d", id)} {
+		w = api.RequestWithHeaders("GET", path, nil, map[string]string{"If-None-Match": songETag(id, 1)})
+		assert.Equal(t, http.StatusOK, w.Code, path)
+		assert.Equal(t, songETag(id, 2), w.Header().Get("ETag"), path)
+	}
+}
+
+func TestConditionalGetOfReplacedSong(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria", Text: "old lyrics"})
+
+	w := api.Request("GET", "/info?group=Muse&song=Hysteria", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	cached := w.Header().Get("ETag")
+
+	w = api.Request("DELETE", fmt.Sprintf("/music/%d", songs[0].ID), nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = api.Request("POST", "/music", []byte(`{"group": "Muse", "song": "Hysteria"}`))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	// the new song is at version 1 as well, but it is not the cached one
+	w = api.RequestWithHeaders("GET", "/info?group=Muse&song=Hysteria", nil, map[string]string{"If-None-Match": cached})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, cached, w.Header().Get("ETag"))
+	assert.NotContains(t, w.Body.String(), "old lyrics")
+}
+
+func TestConditionalGetWithLastModified(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria"})
+	path := fmt.Sprintf("/music/%d", songs[0].ID)
+
+	w := api.Request("GET", path, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	lastModified := w.Header().Get("Last-Modified")
+	modified, err := http.ParseTime(lastModified)
+	require.NoError(t, err)
+
+	w = api.RequestWithHeaders("GET", path, nil, map[string]string{"If-Modified-Since": lastModified})
+	assert.Equal(t, http.StatusNotModified, w.Code)
+
+	w = api.RequestWithHeaders("GET", path, nil, map[string]string{"If-Modified-Since": modified.Add(-time.Second).Format(http.TimeFormat)})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// If-None-Match takes precedence over If-Modified-Since
+	w = api.RequestWithHeaders("GET", path, nil, map[string]string{"If-None-Match": songETag(songs[0].ID, 9), "If-Modified-Since": lastModified})
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestCacheControlPerRoute(t *testing.T) {
+	api := setupAPI(t)
+	routes, err := handlers.ParseCacheControlRoutes("GET /lyrics/:id=private, max-age=300; GET  /info=no-store")
+	require.NoError(t, err)
+	api.Handler.CacheControl = routes
+	api.Handler.DefaultCacheControl = "no-cache"
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria", Text: "It's bugging me"})
+
+	w := api.Request("GET", fmt.Sprintf("/lyrics/%d", songs[0].ID), nil)
+	assert.Equal(t, "private, max-age=300", w.Header().Get("Cache-Control"))
+	w = api.Request("GET", "/info?group=Muse&song=Hysteria", nil)
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	w = api.Request("GET", fmt.Sprintf("/music/%d", songs[0].ID), nil)
+	assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+
+	// errors get no validators or caching policy
+	w = api.Request("GET", "/lyrics/999", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, w.Header().Get("ETag"))
+	assert.Empty(t, w.Header().Get("Cache-Control"))
+}
+
+func TestParseCacheControlRoutesRejectsMalformedEntries(t *testing.T) {
+	for _, value := range []string{"GET /info", "/info=no-store", "GET /info="} {
+		_, err := handlers.ParseCacheControlRoutes(value)
+		assert.Error(t, err, value)
+	}
+
+	routes, err := handlers.ParseCacheControlRoutes("")
+	require.NoError(t, err)
+	assert.Empty(t, routes)
+}
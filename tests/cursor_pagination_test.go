@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListSongsCursorPagination(t *testing.T) {
+	api := setupAPI(t)
+	seedSongs(t, api.Songs,
+		models.Music{Group: "Muse", Title: "Hysteria"},
+		models.Music{Group: "Muse", Title: "Uprising"},
+		models.Music{Group: "Queen", Title: "Bohemian Rhapsody"},
+		models.Music{Group: "Queen", Title: "We Will Rock You"},
+		models.Music{Group: "Radiohead", Title: "Creep"},
+	)
+	titles := func(page types.CursorSongsResponse) []string {
+		titles := []string{}
+		for _, song := range page.Data {
+			titles = append(titles, song.Title)
+		}
+		return titles
+	}
+
+	var first types.CursorSongsResponse
+	w := api.Request("GET", "/music?cursor=&limit=2", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &first)
+	assert.Equal(t, []string{"Hysteria", "Uprising"}, titles(first))
+	assert.Empty(t, first.PrevCursor)
+	require.NotEmpty(t, first.NextCursor)
+
+	// a song inserted at the front while paging does not shift later pages
+	seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Starlight"})
+	require.NoError(t, api.Songs.DeleteSong(1, 0))
+
+	var second types.CursorSongsResponse
+	w = api.Request("GET", "/music?limit=2&cursor="+first.NextCursor, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &second)
+	assert.Equal(t, []string{"Bohemian Rhapsody", "We Will Rock You"}, titles(second))
+	require.NotEmpty(t, second.PrevCursor)
+
+	var last types.CursorSongsResponse
+	w = api.Request("GET", "/music?limit=2&cursor="+second.NextCursor, nil)
+	decodeJSON(t, w.Body.Bytes(), &last)
+	assert.Equal(t, []string{"Creep", "Starlight"}, titles(last))
+	assert.Empty(t, last.NextCursor)
+
+	var back types.CursorSongsResponse
+	w = api.Request("GET", "/music?limit=2&cursor="+second.PrevCursor, nil)
+	decodeJSON(t, w.Body.Bytes(), &back)
+	assert.Equal(t, []string{"Uprising"}, titles(back))
+	assert.Empty(t, back.PrevCursor)
+	assert.NotEmpty(t, back.NextCursor)
+
+	var filtered types.CursorSongsResponse
+	w = api.Request("GET", "/music?cursor=&group=Queen&limit=1", nil)
+	decodeJSON(t, w.Body.Bytes(), &filtered)
+	assert.Equal(t, []string{"Bohemian Rhapsody"}, titles(filtered))
+	var filteredNext types.CursorSongsResponse
+	w = api.Request("GET", "/music?group=Queen&limit=1&cursor="+filtered.NextCursor, nil)
+	decodeJSON(t, w.Body.Bytes(), &filteredNext)
+	assert.Equal(t, []string{"We Will Rock You"}, titles(filteredNext))
+	assert.Empty(t, filteredNext.NextCursor)
+
+	w = api.Request("GET", "/music?cursor=not-a-cursor", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/services"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubEnricher struct {
+	name   string
+	detail *types.SongDetail
+	err    error
+	calls  int32
+}
+
+func (e *stubEnricher) Name() string { return e.name }
+
+func (e *stubEnricher) Enrich(group, title string) (*types.SongDetail, error) {
+	atomic.AddInt32(&e.calls, 1)
+	return e.detail, e.err
+}
+
+func TestEnrichmentChainMergesFieldsInOrder(t *testing.T) {
+	primary := &stubEnricher{name: "primary", detail: &types.SongDetail{Text: "It's bugging me"}}
+	secondary := &stubEnricher{name: "secondary", err: services.ErrSongDetailUnavailable}
+
+	catalogPath := filepath.Join(t.TempDir(), "catalog.json")
+	require.NoError(t, os.WriteFile(catalogPath, []byte(`[
+		{"group": "Muse", "song": "Hysteria", "releaseDate": "01.12.2003", "text": "ignored", "link": "https://example.com/hysteria"}
+	]`), 0o644))
+	catalog, err := services.NewCatalogEnricher("catalog", catalogPath)
+	require.NoError(t, err)
+
+	result, err := services.NewEnrichmentChain(primary, secondary, catalog).Enrich("Muse", "Hysteria")
+	require.NoError(t, err)
+	assert.Equal(t, "It's bugging me", result.Text)
+	assert.Equal(t, "https://example.com/hysteria", result.Link)
+	assert.Equal(t, "2003-12-01", result.ReleaseDate.Format("2006-01-02"))
+	assert.Equal(t, models.FieldSources{ReleaseDate: "catalog", Text: "primary", Link: "catalog"}, result.Sources)
+}
+
+func TestEnrichmentChainAllProvidersFail(t *testing.T) {
+	notFound := &stubEnricher{name: "primary", err: services.ErrSongDetailNotFound}
+	_, err := services.NewEnrichmentChain(notFound).Enrich("Muse", "Hysteria")
+	assert.ErrorIs(t, err, services.ErrSongDetailNotFound)
+
+	down := &stubEnricher{name: "secondary", err: services.ErrSongDetailTimeout}
+	_, err = services.NewEnrichmentChain(notFound, down).Enrich("Muse", "Hysteria")
+	assert.ErrorIs(t, err, services.ErrSongDetailTimeout)
+}
+
+func TestResilientEnricherRetriesTransientErrors(t *testing.T) {
+	down := &stubEnricher{name: "primary", err: services.ErrSongDetailUnavailable}
+	enricher := services.NewResilientEnricher(down, 2, time.Millisecond, nil)
+
+	_, err := enricher.Enrich("Muse", "Hysteria")
+	assert.ErrorIs(t, err, services.ErrSongDetailUnavailable)
+	assert.Equal(t, int32(3), down.calls)
+
+	notFound := &stubEnricher{name: "primary", err: services.ErrSongDetailNotFound}
+	_, err = services.NewResilientEnricher(notFound, 2, time.Millisecond, nil).Enrich("Muse", "Hysteria")
+	assert.ErrorIs(t, err, services.ErrSongDetailNotFound)
+	assert.Equal(t, int32(1), notFound.calls)
+}
+
+func TestResilientEnricherCircuitBreaker(t *testing.T) {
+	down := &stubEnricher{name: "primary", err: services.ErrSongDetailUnavailable}
+	breaker := services.NewCircuitBreaker(2, 50*time.Millisecond)
+	enricher := services.NewResilientEnricher(down, 0, 0, breaker)
+
+	for i := 0; i < 2; i++ {
+		_, err := enricher.Enrich("Muse", "Hysteria")
+		assert.ErrorIs(t, err, services.ErrSongDetailUnavailable)
+	}
+
+	_, err := enricher.Enrich("Muse", "Hysteria")
+	assert.ErrorIs(t, err, services.ErrCircuitOpen)
+	assert.Equal(t, int32(2), down.calls)
+
+	time.Sleep(60 * time.Millisecond)
+	down.err = nil
+	down.detail = &types.SongDetail{Text: "back"}
+	detail, err := enricher.Enrich("Muse", "Hysteria")
+	require.NoError(t, err)
+	assert.Equal(t, "back", detail.Text)
+	assert.True(t, breaker.Allow())
+}
@@ -0,0 +1,192 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/handlers"
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/repositories"
+	"github.com/srmbackisdeveloper/test-music-info/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// songETag is the ETag of song id at version.
+func songETag(id, version uint) string {
+	return fmt.Sprintf(`"%d-%d"`, id, version)
+}
+
+func TestSongETagFollowsVersion(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria"})
+	path := fmt.Sprintf("/music/%d", songs[0].ID)
+
+	w := api.RequestAs("viewer", "GET", path, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, songETag(songs[0].ID, 1), w.Header().Get("ETag"))
+	var song models.Music
+	decodeJSON(t, w.Body.Bytes(), &song)
+	assert.Equal(t, uint(1), song.Version)
+
+	w = api.RequestWithHeaders("PUT", path, []byte(`{"group": "Muse", "title": "Hysteria", "text": "It's bugging me"}`), map[string]string{"If-Match": songETag(songs[0].ID, 1)})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, songETag(songs[0].ID, 2), w.Header().Get("ETag"))
+
+	w = api.RequestWithHeaders("PATCH", path, []byte(`{"link": "https://example.com/hysteria"}`), map[string]string{"If-Match": songETag(songs[0].ID, 0) + ", " + songETag(songs[0].ID, 2)})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, songETag(songs[0].ID, 3), w.Header().Get("ETag"))
+
+	w = api.Request("GET", "/music/999", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestIfMatchRejectsStaleWrites(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria"})
+	path := fmt.Sprintf("/music/%d", songs[0].ID)
+
+	// the first editor wins, the second one read version 1 as well
+	w := api.RequestWithHeaders("PUT", path, []byte(`{"group": "Muse", "title": "Hysteria", "text": "first"}`), map[string]string{"If-Match": songETag(songs[0].ID, 1)})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = api.RequestWithHeaders("PUT", path, []byte(`{"group": "Muse", "title": "Hysteria", "text": "second"}`), map[string]string{"If-Match": songETag(songs[0].ID, 1)})
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code, w.Body.String())
+	w = api.RequestWithHeaders("PATCH", path, []byte(`{"text": "second"}`), map[string]string{"If-Match": songETag(songs[0].ID, 1)})
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code, w.Body.String())
+	w = api.RequestWithHeaders("DELETE", path, nil, map[string]string{"If-Match": songETag(songs[0].ID, 1)})
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code, w.Body.String())
+
+	// If-Match compares strongly, so weak tags never match
+	w = api.RequestWithHeaders("PATCH", path, []byte(`{"text": "second"}`), map[string]string{"If-Match": "W/" + songETag(songs[0].ID, 2)})
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code, w.Body.String())
+
+	song, err := api.Songs.GetSongByID(songs[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "first", song.Text)
+	assert.Equal(t, uint(2), song.Version)
+
+	w = api.RequestWithHeaders("DELETE", path, nil, map[string]string{"If-Match": "*"})
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
+
+func TestRequireIfMatch(t *testing.T) {
+	api := setupAPI(t)
+	api.Handler.RequireIfMatch = true
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria"})
+	path := fmt.Sprintf("/music/%d", songs[0].ID)
+
+	w := api.Request("PUT", path, []byte(`{"group": "Muse", "title": "Hysteria", "text": "first"}`))
+	assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+	w = api.RequestWithHeaders("PATCH", path, []byte(`{"text": "first"}`), map[string]string{"Content-Type": "application/merge-patch+json"})
+	assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+	w = api.Request("DELETE", path, nil)
+	assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+
+	w = api.RequestWithHeaders("PATCH", path, []byte(`{"text": "first"}`), map[string]string{"If-Match": songETag(songs[0].ID, 1)})
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
+
+func TestUpdateSongVersionConflictInStore(t *testing.T) {
+	repo := repositories.NewInMemoryMusicRepository()
+	song := models.Music{Group: "Muse", Title: "Hysteria"}
+	require.NoError(t, repo.AddSong(&song))
+
+	stale := song
+	song.Text = "first"
+	require.NoError(t, repo.UpdateSong(&song))
+	assert.Equal(t, uint(2), song.Version)
+
+	stale.Text = "second"
+	assert.ErrorIs(t, repo.UpdateSong(&stale), repositories.ErrVersionConflict)
+	assert.ErrorIs(t, repo.DeleteSong(song.ID, 1), repositories.ErrVersionConflict)
+	assert.NoError(t, repo.DeleteSong(song.ID, 2))
+}
+
+func TestIfMatchOnUpsertLyricsAndRollback(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria"})
+	id := songs[0].ID
+
+	upsert := "/music?group=muse&song=HYSTERIA"
+	w := api.RequestWithHeaders("PUT", upsert, []byte(`{"text": "first"}`), map[string]string{"If-Match": songETag(id, 1)})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, songETag(id, 2), w.Header().Get("ETag"))
+	w = api.RequestWithHeaders("PUT", upsert, []byte(`{"text": "second"}`), map[string]string{"If-Match": songETag(id, 1)})
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code, w.Body.String())
+	// If-Match never holds for a song that does not exist yet
+	w = api.RequestWithHeaders("PUT", "/music?group=Muse&song=Uprising", []byte(`{}`), map[string]string{"If-Match": "*"})
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code, w.Body.String())
+
+	lyrics := fmt.Sprintf("/lyrics/%d", id)
+	w = api.RequestWithHeaders("PUT", lyrics, []byte("[00:01.00]It's bugging me"), map[string]string{"If-Match": songETag(id, 2)})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, songETag(id, 3), w.Header().Get("ETag"))
+	w = api.RequestWithHeaders("PUT", lyrics, []byte("[00:01.00]Grating me"), map[string]string{"If-Match": songETag(id, 2)})
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code, w.Body.String())
+
+	rollback := fmt.Sprintf("/music/%d/revisions/1/restore", id)
+	w = api.RequestWithHeaders("POST", rollback, nil, map[string]string{"If-Match": songETag(id, 2)})
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code, w.Body.String())
+	w = api.RequestWithHeaders("POST", rollback, nil, map[string]string{"If-Match": songETag(id, 3)})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, songETag(id, 4), w.Header().Get("ETag"))
+
+	song, err := api.Songs.GetSongByID(id)
+	require.NoError(t, err)
+	assert.Empty(t, song.Text)
+	assert.Empty(t, song.SyncedLyrics)
+}
+
+func TestRequireIfMatchOnUpsertLyricsAndRollback(t *testing.T) {
+	api := setupAPI(t)
+	api.Handler.RequireIfMatch = true
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria"})
+	id := songs[0].ID
+
+	w := api.Request("PUT", "/music?group=Muse&song=Hysteria", []byte(`{"text": "first"}`))
+	assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+	w = api.Request("PUT", fmt.Sprintf("/lyrics/%d", id), []byte("[00:01.00]It's bugging me"))
+	assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+	w = api.Request("POST", fmt.Sprintf("/music/%d/revisions/1/restore", id), nil)
+	assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+
+	// creating a song needs no If-Match
+	w = api.Request("PUT", "/music?group=Muse&song=Uprising", []byte(`{}`))
+	assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+}
+
+func TestUploadLyricsVersionConflictIsPreconditionFailed(t *testing.T) {
+	mockService := new(MockMusicService)
+	router := SetupTestRouter()
+	router.PUT("/lyrics/:id", handlers.NewMusicHandler(mockService).UploadLyrics)
+
+	song := &models.Music{ID: 1, Group: "Muse", Title: "Hysteria", Version: 1}
+	mockService.On("GetSongByID", uint(1)).Return(song, nil)
+	mockService.On("SetSyncedLyrics", uint(1), uint(1), "[00:01.00]line", "").Return(nil, services.ErrVersionConflict)
+
+	w := PerformRequest(router, "PUT", "/lyrics/1", []byte("[00:01.00]line"))
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestIfMatchDoesNotHoldForAReplacedSong(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria"})
+	stale := songETag(songs[0].ID, 1)
+
+	w := api.Request("DELETE", fmt.Sprintf("/music/%d", songs[0].ID), nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = api.Request("POST", "/music", []byte(`{"group": "Muse", "song": "Hysteria"}`))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	replacement, err := api.Songs.GetSong("Muse", "Hysteria")
+	require.NoError(t, err)
+	require.Equal(t, uint(1), replacement.Version)
+
+	// the replacement is at version 1 too, but the tag of the old song is stale
+	w = api.RequestWithHeaders("PUT", "/music?group=Muse&song=Hysteria", []byte(`{"text": "stale"}`), map[string]string{"If-Match": stale})
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code, w.Body.String())
+	w = api.RequestWithHeaders("PUT", "/music?group=Muse&song=Hysteria", []byte(`{"text": "fresh"}`), map[string]string{"If-Match": songETag(replacement.ID, 1)})
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
@@ -0,0 +1,130 @@
+package tests
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/repositories"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportSongs(t *testing.T) {
+	api := setupAPI(t)
+	seedSongs(t, api.Songs,
+		models.Music{Group: "Muse", Title: "Hysteria", Text: "It's bugging me,\ngrating me"},
+		models.Music{Group: "Queen", Title: "Bohemian Rhapsody"},
+		models.Music{Group: "Muse", Title: "Uprising", Link: "https://example.com/uprising"},
+	)
+
+	w := api.RequestAs("viewer", "GET", "/music/export", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+	var songs []models.Music
+	decodeJSON(t, w.Body.Bytes(), &songs)
+	require.Len(t, songs, 3)
+	assert.Equal(t, "Hysteria", songs[0].Title)
+
+	w = api.Request("GET", "/music/export?format=csv&group=Muse", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `attachment; filename="songs.csv"`, w.Header().Get("Content-Disposition"))
+	records, err := csv.NewReader(w.Body).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"id", "group", "title", "releaseDate", "text", "link"},
+		{"1", "Muse", "Hysteria", "", "It's bugging me,\ngrating me", ""},
+		{"3", "Muse", "Uprising", "", "", "https://example.com/uprising"},
+	}, records)
+
+	w = api.Request("GET", "/music/export?format=ndjson&title=Bohemian%20Rhapsody", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	lines := 0
+	scanner := bufio.NewScanner(w.Body)
+	for scanner.Scan() {
+		var song models.Music
+		decodeJSON(t, scanner.Bytes(), &song)
+		assert.Equal(t, "Queen", song.Group)
+		lines++
+	}
+	assert.Equal(t, 1, lines)
+
+	w = api.Request("GET", "/music/export?group=Nobody", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "[]", w.Body.String())
+}
+
+func TestExportRoundTripsThroughImport(t *testing.T) {
+	api := setupAPI(t)
+	seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria", Text: "It's bugging me"})
+
+	w := api.Request("GET", "/music/export?format=csv", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var result types.ImportResult
+	w = api.Request("POST", "/music/import?format=csv", []byte(w.Body.String()))
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &result)
+	assert.Equal(t, 1, result.Skipped)
+}
+
+func TestExportSongsErrors(t *testing.T) {
+	api := setupAPI(t)
+
+	w := api.Request("GET", "/music/export?format=xml", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = api.Request("GET", "/music/export?artistId=abc", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "Invalid artist ID")
+}
+
+// failingExportStore fails EachSong after it has passed on FailAfter songs.
+type failingExportStore struct {
+	*repositories.InMemoryMusicRepository
+	FailAfter int
+}
+
+func (s *failingExportStore) EachSong(filter models.SongFilter, fn func(song models.Music) error) error {
+	passed := 0
+	return s.InMemoryMusicRepository.EachSong(filter, func(song models.Music) error {
+		if passed == s.FailAfter {
+			return errors.New("database connection lost")
+		}
+		passed++
+		return fn(song)
+	})
+}
+
+func TestExportSongsFailingMidStreamBreaksTheConnection(t *testing.T) {
+	api := setupAPI(t)
+	seedSongs(t, api.Songs,
+		models.Music{Group: "Muse", Title: "Hysteria"},
+		models.Music{Group: "Muse", Title: "Uprising"},
+		models.Music{Group: "Muse", Title: "Starlight"},
+	)
+	api.Music.MusicRepo = &failingExportStore{InMemoryMusicRepository: api.Songs, FailAfter: 2}
+	server := httptest.NewServer(api.Router)
+	t.Cleanup(server.Close)
+
+	for _, format := range []string{"csv", "json", "ndjson"} {
+		req, err := http.NewRequest("GET", server.URL+"/music/export?format="+format, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+api.tokens["admin"])
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err, format)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode, format)
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF, format)
+		assert.Contains(t, string(body), "Uprising", format)
+		assert.NotContains(t, string(body), "Starlight", format)
+	}
+}
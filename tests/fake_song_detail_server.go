@@ -0,0 +1,34 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+)
+
+// NewFakeSongDetailServer serves the song details API contract from an in-memory map
+// keyed by "group:song", so enrichment can be exercised without the real upstream.
+func NewFakeSongDetailServer(details map[string]types.SongDetail) *httptest.Server {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/info", func(c *gin.Context) {
+		group := c.Query("group")
+		song := c.Query("song")
+		if group == "" || song == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Group and song are required"})
+			return
+		}
+
+		detail, ok := details[group+":"+song]
+		if !ok {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Song not found"})
+			return
+		}
+
+		c.JSON(http.StatusOK, detail)
+	})
+
+	return httptest.NewServer(router)
+}
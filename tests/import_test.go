@@ -0,0 +1,148 @@
+package tests
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportSongsCSV(t *testing.T) {
+	api := setupAPI(t)
+	seedSongs(t, api.Songs,
+		models.Music{Group: "Muse", Title: "Hysteria", Text: "old lyrics"},
+		models.Music{Group: "Queen", Title: "Bohemian Rhapsody", Text: "Is this the real life?"},
+	)
+
+	csv := "group,title,releaseDate,text,link\n" +
+		"Muse,Hysteria,2003-12-01,new lyrics,https://example.com/hysteria\n" +
+		"Queen,Bohemian Rhapsody,,,\n" +
+		"Coldplay,Yellow,2000-06-26,Look at the stars,\n" +
+		",Nameless,,,\n" +
+		"Coldplay,Fix You,01.01.2005,,\n" +
+		"Coldplay,Yellow,,,\n"
+
+	var result types.ImportResult
+	w := api.RequestAs("editor", "POST", "/music/import?format=csv&dryRun=true", []byte(csv))
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &result)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 1, result.Updated)
+	assert.Equal(t, 1, result.Skipped)
+	assert.Equal(t, 3, result.Failed)
+	assert.Equal(t, []string{"updated", "skipped", "created", "failed", "failed", "failed"}, importActions(result))
+	assert.Equal(t, "duplicate of row 3", result.Rows[5].Error)
+
+	count, err := api.Songs.CountSongs(models.SongFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count, "a dry run writes nothing")
+
+	w = api.RequestAs("editor", "POST", "/music/import?format=csv", []byte(csv))
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &result)
+	assert.False(t, result.DryRun)
+	assert.Equal(t, []string{"updated", "skipped", "created", "failed", "failed", "failed"}, importActions(result))
+
+	updated, err := api.Songs.GetSong("Muse", "Hysteria")
+	require.NoError(t, err)
+	assert.Equal(t, "new lyrics", updated.Text)
+	assert.Equal(t, "2003-12-01", updated.ReleaseDate.Format("2006-01-02"))
+	assert.Equal(t, models.SourceImport, updated.Sources.Text)
+
+	created, err := api.Songs.GetSong("Coldplay", "Yellow")
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, result.Rows[2].SongID)
+	assert.Equal(t, "Look at the stars", created.Text)
+	assert.NotNil(t, created.ArtistID)
+
+	var revisions types.PaginatedRevisionsResponse
+	w = api.Request("GET", "/music/1/revisions", nil)
+	decodeJSON(t, w.Body.Bytes(), &revisions)
+	require.Equal(t, 2, revisions.TotalRevisions)
+	assert.Equal(t, "editor", revisions.Data[0].Author)
+
+	w = api.RequestAs("editor", "POST", "/music/import?format=csv", []byte(csv))
+	decodeJSON(t, w.Body.Bytes(), &result)
+	assert.Equal(t, []string{"skipped", "skipped", "skipped", "failed", "failed", "failed"}, importActions(result))
+}
+
+func TestImportSongsJSONAndNDJSON(t *testing.T) {
+	api := setupAPI(t)
+	api.Music.ImportBatchSize = 1
+
+	body := `[{"group": "Muse", "title": "Hysteria", "link": "ftp://example.com"}, {"group": "Muse", "title": "Uprising", "text": "Paranoia is in bloom"}, {"group": 1}]`
+	var result types.ImportResult
+	w := api.Request("POST", "/music/import", []byte(body))
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &result)
+	assert.Equal(t, []string{"failed", "created", "failed"}, importActions(result))
+	assert.Equal(t, "'link' must be an http or https URL", result.Rows[0].Error)
+
+	ndjson := "{\"group\": \"Muse\", \"title\": \"Uprising\", \"releaseDate\": \"2009-09-07\"}\n\nnot json\n{\"group\": \"Muse\", \"title\": \"Starlight\"}\n"
+	w = api.Request("POST", "/music/import?format=ndjson", []byte(ndjson))
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &result)
+	assert.Equal(t, []string{"updated", "failed", "created"}, importActions(result))
+	assert.Equal(t, 3, result.Rows[2].Row)
+
+	uprising, err := api.Songs.GetSong("Muse", "Uprising")
+	require.NoError(t, err)
+	assert.Equal(t, "Paranoia is in bloom", uprising.Text, "empty fields keep the stored value")
+}
+
+func TestImportSongsMultipart(t *testing.T) {
+	api := setupAPI(t)
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	file, err := form.CreateFormFile("file", "songs.ndjson")
+	require.NoError(t, err)
+	_, err = file.Write([]byte(`{"group": "Muse", "title": "Hysteria"}`))
+	require.NoError(t, err)
+	require.NoError(t, form.Close())
+
+	req := httptest.NewRequest("POST", "/music/import", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+api.tokens["editor"])
+	w := httptest.NewRecorder()
+	api.Router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var result types.ImportResult
+	decodeJSON(t, w.Body.Bytes(), &result)
+	assert.Equal(t, 1, result.Created)
+}
+
+func TestImportSongsErrors(t *testing.T) {
+	api := setupAPI(t)
+
+	for name, tc := range map[string]struct {
+		user, path, body string
+		status           int
+	}{
+		"viewer":         {"viewer", "/music/import?format=csv", "group,title\n", http.StatusForbidden},
+		"unknown format": {"editor", "/music/import?format=xml", "<songs/>", http.StatusBadRequest},
+		"bad dry run":    {"editor", "/music/import?format=csv&dryRun=maybe", "group,title\n", http.StatusBadRequest},
+		"no title":       {"editor", "/music/import?format=csv", "group,text\n", http.StatusBadRequest},
+		"not an array":   {"editor", "/music/import?format=json", `{"group": "Muse"}`, http.StatusBadRequest},
+		"broken array":   {"editor", "/music/import?format=json", `[{"group": "Muse"`, http.StatusBadRequest},
+	} {
+		w := api.RequestAs(tc.user, "POST", tc.path, []byte(tc.body))
+		assert.Equal(t, tc.status, w.Code, name)
+	}
+}
+
+func importActions(result types.ImportResult) []string {
+	actions := make([]string, len(result.Rows))
+	for i, row := range result.Rows {
+		actions[i] = row.Action
+	}
+	return actions
+}
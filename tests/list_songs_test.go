@@ -0,0 +1,133 @@
+package tests
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func seedCatalog(t *testing.T, api *testAPI) {
+	date := func(value string) time.Time {
+		parsed, err := time.Parse("2006-01-02", value)
+		require.NoError(t, err)
+		return parsed
+	}
+	seedSongs(t, api.Songs,
+		models.Music{Group: "Queen", Title: "We Will Rock You", ReleaseDate: date("1977-10-07"), Text: "Buddy, you're a boy"},
+		models.Music{Group: "Muse", Title: "Uprising", ReleaseDate: date("2009-09-07"), Link: "https://example.com/uprising"},
+		models.Music{Group: "queen", Title: "bohemian Rhapsody", ReleaseDate: date("1975-10-31"), Text: "Is this the real life?", Link: "https://example.com/bohemian"},
+		models.Music{Group: "Muse", Title: "Hysteria", ReleaseDate: date("2003-12-01")},
+		models.Music{Group: "Radiohead", Title: "Creep", SyncedLyrics: models.LyricLines{{TimeMs: 0, Text: "When you were here before"}}},
+	)
+}
+
+func listTitles(t *testing.T, api *testAPI, path string) []string {
+	w := api.Request("GET", path, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var page types.PaginatedSongsResponse
+	decodeJSON(t, w.Body.Bytes(), &page)
+	titles := []string{}
+	for _, song := range page.Data {
+		titles = append(titles, song.Title)
+	}
+	return titles
+}
+
+func TestListSongsSort(t *testing.T) {
+	api := setupAPI(t)
+	seedCatalog(t, api)
+
+	assert.Equal(t, []string{"bohemian Rhapsody", "Creep", "Hysteria", "Uprising", "We Will Rock You"},
+		listTitles(t, api, "/music?sort=title"))
+	assert.Equal(t, []string{"We Will Rock You", "Uprising", "Hysteria", "Creep", "bohemian Rhapsody"},
+		listTitles(t, api, "/music?sort=title:desc"))
+
+	// group ties are ordered by the second key, case-insensitively
+	assert.Equal(t, []string{"Uprising", "Hysteria", "We Will Rock You", "bohemian Rhapsody", "Creep"},
+		listTitles(t, api, "/music?sort=group,releaseDate:desc"))
+
+	// unknown release dates sort first
+	assert.Equal(t, []string{"Creep", "bohemian Rhapsody", "We Will Rock You", "Hysteria", "Uprising"},
+		listTitles(t, api, "/music?sort=releaseDate:asc"))
+
+	assert.Equal(t, []string{"We Will Rock You", "Uprising"},
+		listTitles(t, api, "/music?sort=createdAt&limit=2"))
+
+	for _, sort := range []string{"group_name", "title:up", "id", "title,title:desc", "title;DROP TABLE musics"} {
+		w := api.Request("GET", "/music?sort="+url.QueryEscape(sort), nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, sort)
+	}
+}
+
+func TestListSongsFilters(t *testing.T) {
+	api := setupAPI(t)
+	seedCatalog(t, api)
+
+	assert.ElementsMatch(t, []string{"We Will Rock You", "bohemian Rhapsody"},
+		listTitles(t, api, "/music?group=QUEE"))
+	assert.Equal(t, []string{"Creep"}, listTitles(t, api, "/music?title=ree"))
+	assert.Empty(t, listTitles(t, api, "/music?title=%25"))
+
+	assert.Equal(t, []string{"Uprising", "Hysteria"},
+		listTitles(t, api, "/music?releasedFrom=2003-12-01&sort=releaseDate:desc"))
+	assert.Equal(t, []string{"bohemian Rhapsody", "We Will Rock You"},
+		listTitles(t, api, "/music?releasedTo=1977-10-07&sort=releaseDate"))
+	assert.Equal(t, []string{"Hysteria"},
+		listTitles(t, api, "/music?releasedFrom=2000-01-01&releasedTo=2005-01-01"))
+
+	assert.Equal(t, []string{"We Will Rock You", "bohemian Rhapsody", "Creep"},
+		listTitles(t, api, "/music?hasLyrics=true"))
+	assert.Equal(t, []string{"Uprising", "Hysteria"}, listTitles(t, api, "/music?hasLyrics=false"))
+	assert.Equal(t, []string{"bohemian Rhapsody"}, listTitles(t, api, "/music?hasLyrics=true&hasLink=1"))
+
+	for path, message := range map[string]string{
+		"/music?releasedFrom=07.10.1977":                       "'releasedFrom' must look like 2006-01-02",
+		"/music?releasedFrom=2001-01-01&releasedTo=2000-01-01": "'releasedFrom' must not be after 'releasedTo'",
+		"/music?hasLink=maybe":                                 "'hasLink' must be true or false",
+		"/music?artistId=x":                                    "Invalid artist ID",
+		"/music/export?hasLyrics=yes":                          "'hasLyrics' must be true or false",
+	} {
+		w := api.Request("GET", path, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, path)
+		assert.Contains(t, w.Body.String(), message, path)
+	}
+}
+
+func TestListSongsCursorWithSort(t *testing.T) {
+	api := setupAPI(t)
+	seedCatalog(t, api)
+
+	var first types.CursorSongsResponse
+	w := api.Request("GET", "/music?sort=group:desc,title&cursor=&limit=2", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &first)
+	require.Len(t, first.Data, 2)
+	assert.Equal(t, "Creep", first.Data[0].Title)
+	assert.Equal(t, "bohemian Rhapsody", first.Data[1].Title)
+
+	var second types.CursorSongsResponse
+	w = api.Request("GET", "/music?sort=group:desc,title&limit=2&cursor="+first.NextCursor, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &second)
+	require.Len(t, second.Data, 2)
+	assert.Equal(t, "We Will Rock You", second.Data[0].Title)
+	assert.Equal(t, "Hysteria", second.Data[1].Title)
+
+	var back types.CursorSongsResponse
+	w = api.Request("GET", "/music?sort=group:desc,title&limit=2&cursor="+second.PrevCursor, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &back)
+	assert.Equal(t, first.Data, back.Data)
+	assert.Empty(t, back.PrevCursor)
+
+	// a cursor only works with the sort order it was issued for
+	w = api.Request("GET", "/music?sort=title&limit=2&cursor="+first.NextCursor, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeLookupKey(t *testing.T) {
+	for input, expected := range map[string]string{
+		"Muse":                "muse",
+		"  The   Beatles ":    "the beatles",
+		"Beyoncé":             "beyonce",
+		"ＭＵＳＥ":                "muse",
+		"AC/DC":               "ac dc",
+		"Guns N' Roses":       "guns n roses",
+		"Straße":              "strasse",
+		"Hey Jude!!! (Remix)": "hey jude remix",
+	} {
+		assert.Equal(t, expected, models.NormalizeLookupKey(input), input)
+	}
+}
+
+func TestNormalizeLookupKeyConcurrently(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				assert.Equal(t, "strasse", models.NormalizeLookupKey("STRASSE"))
+				assert.Equal(t, "sigur ros hoppipolla", models.NormalizeLookupKey("Sigur Rós – Hoppípolla"))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func infoPath(group, title string) string {
+	return "/info?" + url.Values{"group": {group}, "song": {title}}.Encode()
+}
+
+func TestGetInfoNormalizedLookup(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Sigur Rós", Title: "Hoppípolla", Text: "Brosandi"})
+
+	for _, spelling := range [][2]string{
+		{"Sigur Rós", "Hoppípolla"},
+		{"sigur ros", "hoppipolla"},
+		{"  SIGUR   RÓS ", "Hoppípolla."},
+	} {
+		w := api.Request("GET", infoPath(spelling[0], spelling[1]), nil)
+		require.Equal(t, http.StatusOK, w.Code, spelling)
+
+		var detail types.SongDetail
+		decodeJSON(t, w.Body.Bytes(), &detail)
+		assert.Equal(t, "Brosandi", detail.Text)
+	}
+
+	// a rename evicts the shared cache entry of every spelling of the old name
+	w := api.Request("PUT", fmt.Sprintf("/music/%d", songs[0].ID), []byte(`{"group": "Sigur Rós", "title": "Glósóli", "text": "Brosandi"}`))
+	require.Equal(t, http.StatusOK, w.Code)
+	w = api.Request("GET", infoPath("SIGUR ROS", "hoppipolla"), nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = api.Request("GET", infoPath("sigur rós", "glosoli"), nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestGetInfoSuggestions(t *testing.T) {
+	api := setupAPI(t)
+	seedSongs(t, api.Songs,
+		models.Music{Group: "Muse", Title: "Hysteria"},
+		models.Music{Group: "Muse", Title: "Uprising"},
+		models.Music{Group: "Queen", Title: "Bohemian Rhapsody"},
+	)
+
+	w := api.Request("GET", infoPath("Muse", "Hysterya"), nil)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	var notFound types.SongNotFoundResponse
+	decodeJSON(t, w.Body.Bytes(), &notFound)
+	assert.Equal(t, "Song not found", notFound.Error)
+	require.NotEmpty(t, notFound.Suggestions)
+	assert.Equal(t, types.SongSuggestion{ID: 1, Group: "Muse", Title: "Hysteria"}, notFound.Suggestions[0])
+
+	w = api.Request("GET", infoPath("Metallica", "One"), nil)
+	require.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"error": "Song not found", "suggestions": []}`, w.Body.String())
+}
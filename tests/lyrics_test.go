@@ -0,0 +1,167 @@
+package tests
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/services"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const hysteriaLRC = `[ar:Muse]
+[ti:Hysteria]
+[offset:+500]
+
+[00:10.50]It's bugging me
+[00:14.00][01:20.25]Grating me
+[00:18.1]And twisting me around
+`
+
+func TestParseLRC(t *testing.T) {
+	lines, err := services.ParseLRC(hysteriaLRC)
+	require.NoError(t, err)
+	assert.Equal(t, models.LyricLines{
+		{TimeMs: 10000, Text: "It's bugging me"},
+		{TimeMs: 13500, Text: "Grating me"},
+		{TimeMs: 17600, Text: "And twisting me around"},
+		{TimeMs: 79750, Text: "Grating me"},
+	}, lines)
+	assert.Equal(t, "[00:10.00]It's bugging me\n[00:13.50]Grating me\n[00:17.60]And twisting me around\n[01:19.75]Grating me\n", lines.String())
+
+	for name, lrc := range map[string]string{
+		"no timestamp":   "[00:01.00]fine\nnot fine",
+		"bad seconds":    "[00:61.00]too late",
+		"bad offset":     "[offset:soon]\n[00:01.00]line",
+		"no timed lines": "[ar:Muse]\n\n",
+	} {
+		_, err := services.ParseLRC(lrc)
+		assert.ErrorIs(t, err, services.ErrInvalidLRC, name)
+	}
+}
+
+func TestSyncedLyrics(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria", Text: "It's bugging me\n\nGrating me"})
+	path := fmt.Sprintf("/lyrics/%d", songs[0].ID)
+
+	w := api.Request("GET", path+"?format=lrc", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = api.RequestAs("viewer", "PUT", path, []byte(hysteriaLRC))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = api.RequestAs("editor", "PUT", path, []byte("[00:01.00]fine\nnot fine"))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "line 2 has no timestamp")
+	w = api.RequestAs("editor", "PUT", path, []byte(hysteriaLRC))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = api.Request("GET", path+"?format=lrc", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "[00:13.50]Grating me\n")
+
+	var synced types.SyncedLyricsResponse
+	w = api.Request("GET", path+"?format=json", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &synced)
+	require.Len(t, synced.Lines, 4)
+	assert.Equal(t, int64(10000), synced.Lines[0].TimeMs)
+
+	// the plain text verses are unchanged
+	var verses types.PaginatedVersesResponse
+	w = api.Request("GET", path, nil)
+	decodeJSON(t, w.Body.Bytes(), &verses)
+	assert.Equal(t, 2, verses.TotalVerses)
+
+	var active types.ActiveLyricLineResponse
+	w = api.Request("GET", path+"/line?position=15.2", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &active)
+	assert.Equal(t, int64(15200), active.PositionMs)
+	assert.Equal(t, 1, active.Index)
+	assert.Equal(t, "Grating me", active.Line.Text)
+	assert.Equal(t, "And twisting me around", active.Next.Text)
+
+	w = api.Request("GET", path+"/line?position=5s", nil)
+	decodeJSON(t, w.Body.Bytes(), &active)
+	assert.Equal(t, -1, active.Index)
+	assert.Nil(t, active.Line)
+	assert.Equal(t, int64(10000), active.Next.TimeMs)
+
+	w = api.Request("GET", path+"/line?position=2m", nil)
+	decodeJSON(t, w.Body.Bytes(), &active)
+	assert.Equal(t, 3, active.Index)
+	assert.Nil(t, active.Next)
+
+	var revisions types.PaginatedRevisionsResponse
+	w = api.Request("GET", fmt.Sprintf("/music/%d/revisions", songs[0].ID), nil)
+	decodeJSON(t, w.Body.Bytes(), &revisions)
+	require.NotEmpty(t, revisions.Data)
+	assert.Equal(t, "syncedLyrics", revisions.Data[0].Changes[0].Field)
+}
+
+func TestSyncedLyricsErrors(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria"})
+	path := fmt.Sprintf("/lyrics/%d", songs[0].ID)
+
+	for _, tc := range []struct {
+		method, path, body string
+		status             int
+	}{
+		{"GET", path + "?format=xml", "", http.StatusBadRequest},
+		{"GET", path + "/line?position=-1", "", http.StatusBadRequest},
+		{"GET", path + "/line?position=soon", "", http.StatusBadRequest},
+		{"GET", path + "/line?position=1", "", http.StatusNotFound},
+		{"GET", "/lyrics/999/line?position=1", "", http.StatusNotFound},
+		{"PUT", "/lyrics/999", "[00:01.00]line", http.StatusNotFound},
+		{"PUT", "/lyrics/abc", "[00:01.00]line", http.StatusBadRequest},
+	} {
+		w := api.Request(tc.method, tc.path, []byte(tc.body))
+		assert.Equal(t, tc.status, w.Code, tc.method+" "+tc.path)
+	}
+}
+
+func TestUploadLyricsErrorsAreReported(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria"})
+	path := fmt.Sprintf("/lyrics/%d", songs[0].ID)
+	tooLarge := strings.Repeat("[00:01.00]line\n", 1<<20/15+1)
+
+	multipartUpload := func(field, content string) ([]byte, map[string]string) {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		part, err := writer.CreateFormFile(field, "hysteria.lrc")
+		require.NoError(t, err)
+		_, err = part.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+		return body.Bytes(), map[string]string{"Content-Type": writer.FormDataContentType()}
+	}
+
+	w := api.Request("PUT", path, []byte(tooLarge))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code, w.Body.String())
+
+	body, headers := multipartUpload("file", tooLarge)
+	w = api.RequestWithHeaders("PUT", path, body, headers)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code, w.Body.String())
+
+	body, headers = multipartUpload("lyrics", hysteriaLRC)
+	w = api.RequestWithHeaders("PUT", path, body, headers)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "multipart uploads need a 'file' field")
+
+	w = api.RequestWithHeaders("PUT", path, []byte("not multipart"), map[string]string{"Content-Type": "multipart/form-data; boundary=x"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "failed to read the multipart upload")
+
+	body, headers = multipartUpload("file", hysteriaLRC)
+	w = api.RequestWithHeaders("PUT", path, body, headers)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"os"
+	"testing"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/migrations"
+	"github.com/srmbackisdeveloper/test-music-info/internal/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// baselineSchema is the musics table the old AutoMigrate startup created,
+// before the migrations existed.
+const baselineSchema = `
+CREATE TABLE musics (
+    id           bigserial PRIMARY KEY,
+    group_name   text NOT NULL,
+    title        text NOT NULL,
+    release_date date,
+    text         text,
+    link         text,
+    created_at   timestamptz,
+    updated_at   timestamptz
+);`
+
+// setupPostgres connects to TEST_POSTGRES_DSN and empties its public schema,
+// so it must point at a throwaway database. Tests using it are skipped when
+// the variable is not set.
+func setupPostgres(t *testing.T) *gorm.DB {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+
+	db, err := repositories.NewPostgresDB(dsn)
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	require.NoError(t, db.Exec("DROP SCHEMA public CASCADE; CREATE SCHEMA public;").Error)
+	return db
+}
+
+func TestMigrateFromBaselineSchema(t *testing.T) {
+	db := setupPostgres(t)
+	require.NoError(t, db.Exec(baselineSchema).Error)
+	require.NoError(t, db.Exec(`INSERT INTO musics (group_name, title, text, created_at, updated_at)
+		VALUES ('Muse', 'Hysteria', 'It''s bugging me', now(), now())`).Error)
+
+	require.NoError(t, repositories.MigrateDB(db))
+
+	for _, column := range []string{"artist_id", "source_release_date", "source_text", "source_link", "deleted_at", "group_key", "title_key", "version"} {
+		assert.True(t, db.Migrator().HasColumn("musics", column), column)
+	}
+	assert.True(t, db.Migrator().HasIndex("musics", "idx_musics_artist_id"))
+
+	songs := repositories.NewMusicRepository(db)
+	song, err := songs.GetSong("muse", "HYSTERIA")
+	require.NoError(t, err)
+	assert.NotNil(t, song.ArtistID)
+	assert.Equal(t, uint(1), song.Version)
+
+	revision, err := repositories.NewRevisionRepository(db).LatestRevision(song.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, revision.Revision)
+}
+
+func TestMigrateRejectsDuplicateSongsFromBaselineSchema(t *testing.T) {
+	db := setupPostgres(t)
+	require.NoError(t, db.Exec(baselineSchema).Error)
+	require.NoError(t, db.Exec(`INSERT INTO musics (group_name, title, created_at, updated_at)
+		VALUES ('Sigur Rós', 'Hoppípolla', now(), now()), ('sigur ros', 'HOPPIPOLLA', now(), now())`).Error)
+
+	err := repositories.MigrateDB(db)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "songs with the same normalized group and title")
+	assert.False(t, db.Migrator().HasIndex("musics", "idx_musics_lookup_keys_unique"))
+
+	// once the duplicate is gone the migration goes through
+	require.NoError(t, db.Exec(`DELETE FROM musics WHERE group_name = 'sigur ros'`).Error)
+	require.NoError(t, repositories.MigrateDB(db))
+	assert.True(t, db.Migrator().HasIndex("musics", "idx_musics_lookup_keys_unique"))
+
+	_, err = repositories.NewMusicRepository(db).GetSong("sigur ros", "hoppipolla")
+	assert.NoError(t, err)
+}
+
+func TestBackfillAfterPartialMigration(t *testing.T) {
+	db := setupPostgres(t)
+	all, err := migrations.All()
+	require.NoError(t, err)
+	migrator, err := repositories.NewMigrator(db, all)
+	require.NoError(t, err)
+
+	// up to 0009, before the lookup key columns exist
+	_, err = migrator.Up(9)
+	require.NoError(t, err)
+	require.NoError(t, db.Exec(`INSERT INTO musics (group_name, title, created_at, updated_at)
+		VALUES ('Muse', 'Hysteria', now(), now())`).Error)
+	require.NoError(t, repositories.Backfill(db, migrator))
+
+	require.NoError(t, repositories.MigrateDB(db))
+	song, err := repositories.NewMusicRepository(db).GetSong("muse", "hysteria")
+	require.NoError(t, err)
+	assert.NotNil(t, song.ArtistID)
+}
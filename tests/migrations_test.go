@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	all, err := migrations.All()
+	require.NoError(t, err)
+	require.NotEmpty(t, all)
+
+	for i, migration := range all {
+		assert.Equal(t, int64(i+1), migration.Version, "versions must be contiguous")
+		assert.True(t, migration.UpInTransaction())
+	}
+	assert.Equal(t, "create_artists_and_musics", all[0].Name)
+}
+
+func TestLoadMigrations(t *testing.T) {
+	loaded, err := migrations.Load(fstest.MapFS{
+		"sql/0002_add_index.up.sql":      {Data: []byte("-- migrate:no-transaction\nCREATE INDEX CONCURRENTLY idx ON t (c);")},
+		"sql/0002_add_index.down.sql":    {Data: []byte("DROP INDEX idx;")},
+		"sql/0001_create_table.up.sql":   {Data: []byte("CREATE TABLE t (c int);")},
+		"sql/0001_create_table.down.sql": {Data: []byte("DROP TABLE t;")},
+	}, "sql")
+	require.NoError(t, err)
+	require.Len(t, loaded, 2)
+	assert.Equal(t, "create_table", loaded[0].Name)
+	assert.Equal(t, "DROP TABLE t;", loaded[0].Down)
+	assert.True(t, loaded[0].UpInTransaction())
+	assert.False(t, loaded[1].UpInTransaction())
+	assert.True(t, loaded[1].DownInTransaction())
+}
+
+func TestLoadMigrationsErrors(t *testing.T) {
+	for name, fsys := range map[string]fstest.MapFS{
+		"missing down": {
+			"sql/0001_create_table.up.sql": {Data: []byte("CREATE TABLE t (c int);")},
+		},
+		"conflicting names": {
+			"sql/0001_create_table.up.sql": {Data: []byte("CREATE TABLE t (c int);")},
+			"sql/0001_drop_table.down.sql": {Data: []byte("DROP TABLE t;")},
+		},
+		"no version": {
+			"sql/create_table.up.sql":   {Data: []byte("CREATE TABLE t (c int);")},
+			"sql/create_table.down.sql": {Data: []byte("DROP TABLE t;")},
+		},
+	} {
+		_, err := migrations.Load(fsys, "sql")
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,129 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func patchSong(api *testAPI, id uint, contentType, body string) (int, string) {
+	w := api.RequestWithHeaders("PATCH", fmt.Sprintf("/music/%d", id), []byte(body), map[string]string{"Content-Type": contentType})
+	return w.Code, w.Body.String()
+}
+
+func TestPatchSongMergePatch(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria", Text: "It's bugging me", Link: "https://example.com/old"})
+	id := songs[0].ID
+
+	code, body := patchSong(api, id, "application/merge-patch+json", `{"link": "https://example.com/new"}`)
+	require.Equal(t, http.StatusOK, code, body)
+	var response struct {
+		Song models.Music `json:"song"`
+	}
+	decodeJSON(t, []byte(body), &response)
+	assert.Equal(t, "https://example.com/new", response.Song.Link)
+	assert.Equal(t, "It's bugging me", response.Song.Text)
+	assert.Equal(t, models.SourceManual, response.Song.Sources.Link)
+	assert.Empty(t, response.Song.Sources.Text)
+
+	// null clears a field; plain application/json objects are merge patches
+	code, body = patchSong(api, id, "application/json", `{"text": null, "releaseDate": "2003-12-01"}`)
+	require.Equal(t, http.StatusOK, code, body)
+	song, err := api.Songs.GetSongByID(id)
+	require.NoError(t, err)
+	assert.Empty(t, song.Text)
+	assert.Equal(t, "2003-12-01", song.ReleaseDate.Format("2006-01-02"))
+	assert.Equal(t, "https://example.com/new", song.Link)
+
+	revisions, err := api.Revisions.ListRevisions(id, 10, 0)
+	require.NoError(t, err)
+	require.NotEmpty(t, revisions)
+	assert.Equal(t, models.FieldChanges{
+		{Field: "releaseDate", Before: "", After: "2003-12-01"},
+		{Field: "text", Before: "It's bugging me", After: ""},
+	}, revisions[0].Changes)
+}
+
+func TestPatchSongJSONPatch(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria", Text: "It's bugging me"})
+	id := songs[0].ID
+
+	code, body := patchSong(api, id, "application/json-patch+json", `[
+		{"op": "test", "path": "/title", "value": "Hysteria"},
+		{"op": "copy", "from": "/text", "path": "/link"},
+		{"op": "replace", "path": "/link", "value": "https://example.com/hysteria"},
+		{"op": "replace", "path": "/text", "value": "Grating me"}
+	]`)
+	require.Equal(t, http.StatusOK, code, body)
+	song, err := api.Songs.GetSongByID(id)
+	require.NoError(t, err)
+	assert.Equal(t, "Grating me", song.Text)
+	assert.Equal(t, "https://example.com/hysteria", song.Link)
+
+	// a failing test leaves the song untouched
+	code, _ = patchSong(api, id, "application/json", `[
+		{"op": "replace", "path": "/text", "value": "changed"},
+		{"op": "test", "path": "/title", "value": "Uprising"}
+	]`)
+	assert.Equal(t, http.StatusConflict, code)
+	song, err = api.Songs.GetSongByID(id)
+	require.NoError(t, err)
+	assert.Equal(t, "Grating me", song.Text)
+
+	code, body = patchSong(api, id, "application/json-patch+json", `[{"op": "remove", "path": "/link"}]`)
+	require.Equal(t, http.StatusOK, code, body)
+	song, err = api.Songs.GetSongByID(id)
+	require.NoError(t, err)
+	assert.Empty(t, song.Link)
+}
+
+func TestPatchSongErrors(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs,
+		models.Music{Group: "Muse", Title: "Hysteria"},
+		models.Music{Group: "Muse", Title: "Uprising"},
+	)
+	id := songs[0].ID
+
+	for _, tc := range []struct {
+		contentType string
+		body        string
+		code        int
+		message     string
+	}{
+		{"application/merge-patch+json", `not json`, http.StatusBadRequest, "invalid patch"},
+		{"application/merge-patch+json", `"text"`, http.StatusBadRequest, "must be a JSON object"},
+		{"application/json-patch+json", `{"op": "add"}`, http.StatusBadRequest, "array of operations"},
+		{"application/json-patch+json", `[{"op": "add", "path": "/id", "value": 2}]`, http.StatusBadRequest, "unknown field"},
+		{"application/json-patch+json", `[{"op": "add", "path": "/text/0", "value": "x"}]`, http.StatusBadRequest, "must name one song field"},
+		{"application/json-patch+json", `[{"op": "jump", "path": "/text"}]`, http.StatusBadRequest, "unknown operation"},
+		{"application/json-patch+json", `[{"op": "replace", "path": "/text"}]`, http.StatusBadRequest, "missing 'value'"},
+		{"application/merge-patch+json", `{"sources": {"text": "x"}}`, http.StatusUnprocessableEntity, "unknown field"},
+		{"application/merge-patch+json", `{"title": null}`, http.StatusUnprocessableEntity, "must not be empty"},
+		{"application/merge-patch+json", `{"text": 5}`, http.StatusUnprocessableEntity, "'text' must be a string"},
+		{"application/merge-patch+json", `{"releaseDate": "01.12.2003"}`, http.StatusUnprocessableEntity, "2006-01-02"},
+		{"application/merge-patch+json", `{"link": "ftp://example.com"}`, http.StatusUnprocessableEntity, "http or https"},
+		{"application/merge-patch+json", `{"title": "UPRISING"}`, http.StatusConflict, "already exists"},
+		{"text/plain", `{}`, http.StatusUnsupportedMediaType, "Content type"},
+	} {
+		code, body := patchSong(api, id, tc.contentType, tc.body)
+		assert.Equal(t, tc.code, code, tc.body)
+		assert.Contains(t, body, tc.message, tc.body)
+	}
+
+	code, _ := patchSong(api, 999, "application/merge-patch+json", `{}`)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, _ = patchSong(api, id, "application/merge-patch+json", `{"text": "`+strings.Repeat("a", 1<<20)+`"}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, code)
+
+	w := api.RequestAs("viewer", "PATCH", fmt.Sprintf("/music/%d", id), []byte(`{"text": "x"}`))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
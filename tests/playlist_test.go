@@ -0,0 +1,153 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func playlistSongIDs(playlist models.Playlist) []uint {
+	ids := []uint{}
+	for _, entry := range playlist.Entries {
+		ids = append(ids, entry.SongID)
+	}
+	return ids
+}
+
+func TestPlaylistEntries(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs,
+		models.Music{Group: "Muse", Title: "Hysteria"},
+		models.Music{Group: "Queen", Title: "Bohemian Rhapsody"},
+		models.Music{Group: "Radiohead", Title: "Creep"},
+	)
+
+	w := api.RequestAs("alice", "POST", "/playlists", []byte(`{"name": "Road trip"}`))
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created struct {
+		Playlist models.Playlist `json:"playlist"`
+	}
+	decodeJSON(t, w.Body.Bytes(), &created)
+	assert.Equal(t, "alice", created.Playlist.Owner)
+	assert.Equal(t, models.VisibilityPrivate, created.Playlist.Visibility)
+	path := fmt.Sprintf("/playlists/%d", created.Playlist.ID)
+
+	var updated struct {
+		Playlist models.Playlist `json:"playlist"`
+	}
+	for _, song := range songs {
+		w = api.RequestAs("alice", "POST", path+"/entries", []byte(fmt.Sprintf(`{"songId": %d}`, song.ID)))
+		require.Equal(t, http.StatusCreated, w.Code)
+	}
+	w = api.RequestAs("alice", "POST", path+"/entries", []byte(fmt.Sprintf(`{"songId": %d, "position": 1}`, songs[2].ID)))
+	require.Equal(t, http.StatusCreated, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &updated)
+	assert.Equal(t, []uint{songs[2].ID, songs[0].ID, songs[1].ID, songs[2].ID}, playlistSongIDs(updated.Playlist))
+
+	moved := updated.Playlist.Entries[3]
+	w = api.RequestAs("alice", "PUT", fmt.Sprintf("%s/entries/%d", path, moved.ID), []byte(`{"position": 2}`))
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &updated)
+	assert.Equal(t, []uint{songs[2].ID, songs[2].ID, songs[0].ID, songs[1].ID}, playlistSongIDs(updated.Playlist))
+	assert.Equal(t, moved.ID, updated.Playlist.Entries[1].ID)
+
+	w = api.RequestAs("alice", "DELETE", fmt.Sprintf("%s/entries/%d", path, updated.Playlist.Entries[0].ID), nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = api.RequestAs("alice", "GET", path, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var playlist models.Playlist
+	decodeJSON(t, w.Body.Bytes(), &playlist)
+	assert.Equal(t, []uint{songs[2].ID, songs[0].ID, songs[1].ID}, playlistSongIDs(playlist))
+	for i, entry := range playlist.Entries {
+		assert.Equal(t, i+1, entry.Position)
+		require.NotNil(t, entry.Song)
+	}
+}
+
+func TestPlaylistEntryErrors(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria"})
+
+	w := api.Request("POST", "/playlists", []byte(`{"name": "Mix", "visibility": "secret"}`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = api.Request("POST", "/playlists", []byte(`{"name": "Mix"}`))
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w = api.Request("POST", "/playlists/1/entries", []byte(`{"songId": 999}`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = api.Request("POST", "/playlists/1/entries", []byte(fmt.Sprintf(`{"songId": %d, "position": 3}`, songs[0].ID)))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = api.Request("POST", "/playlists/999/entries", []byte(fmt.Sprintf(`{"songId": %d}`, songs[0].ID)))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = api.Request("PUT", "/playlists/1/entries/999", []byte(`{"position": 1}`))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = api.Request("DELETE", "/playlists/1/entries/abc", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestListPlaylists(t *testing.T) {
+	api := setupAPI(t)
+	for _, playlist := range []struct{ owner, body string }{
+		{"alice", `{"name": "Public A", "visibility": "public"}`},
+		{"alice", `{"name": "Private A"}`},
+		{"bob", `{"name": "Public B", "visibility": "public"}`},
+	} {
+		w := api.RequestAs(playlist.owner, "POST", "/playlists", []byte(playlist.body))
+		require.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	w := api.RequestAs("bob", "GET", "/playlists?limit=1", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var page types.PaginatedPlaylistsResponse
+	decodeJSON(t, w.Body.Bytes(), &page)
+	assert.Equal(t, 2, page.TotalPlaylists)
+	assert.Equal(t, 2, page.TotalPages)
+	require.Len(t, page.Data, 1)
+
+	w = api.RequestAs("alice", "GET", "/playlists?owner=alice", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &page)
+	assert.Equal(t, 2, page.TotalPlaylists)
+
+	w = api.RequestAs("bob", "GET", "/playlists?owner=alice", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &page)
+	assert.Equal(t, 1, page.TotalPlaylists)
+
+	w = api.Request("GET", "/playlists?owner=alice", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &page)
+	assert.Equal(t, 2, page.TotalPlaylists)
+}
+
+func TestPlaylistOwnership(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria"})
+	entry := []byte(fmt.Sprintf(`{"songId": %d}`, songs[0].ID))
+
+	w := api.RequestAs("alice", "POST", "/playlists", []byte(`{"name": "Secret"}`))
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w = api.RequestAs("bob", "GET", "/playlists/1", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = api.RequestAs("bob", "POST", "/playlists/1/entries", entry)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = api.RequestAs("alice", "POST", "/playlists/1/entries", entry)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	w = api.Request("GET", "/playlists/1", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/server"
+	"github.com/srmbackisdeveloper/test-music-info/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitPerRouteAndClient(t *testing.T) {
+	api := setupAPI(t)
+	seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria"})
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	api.Limiter.Now = func() time.Time { return now }
+	api.Limiter.Routes["GET /info"] = services.RateLimitRule{Limit: 2, Window: time.Minute}
+
+	for _, remaining := range []string{"1", "0"} {
+		w := api.RequestAs("alice", "GET", "/info?group=Muse&song=Hysteria", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "2", w.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, remaining, w.Header().Get("X-RateLimit-Remaining"))
+		now = now.Add(10 * time.Second)
+	}
+
+	w := api.RequestAs("alice", "GET", "/info?group=Muse&song=Hysteria", nil)
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "40", w.Header().Get("Retry-After"))
+	assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+
+	w = api.RequestAs("bob", "GET", "/info?group=Muse&song=Hysteria", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = api.RequestAs("alice", "GET", "/music", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1000", w.Header().Get("X-RateLimit-Limit"))
+
+	now = now.Add(41 * time.Second)
+	w = api.RequestAs("alice", "GET", "/info?group=Muse&song=Hysteria", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRateLimitLoginByIP(t *testing.T) {
+	api := setupAPI(t)
+	api.Limiter.Routes["POST /auth/login"] = services.RateLimitRule{Limit: 1, Window: time.Minute}
+
+	w := PerformRequest(api.Router, "POST", "/auth/login", []byte(`{"username": "viewer", "password": "wrong"}`))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = PerformRequest(api.Router, "POST", "/auth/login", []byte(`{"username": "viewer", "password": "viewer-password"}`))
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+}
+
+func TestRateLimitLoginIgnoresForwardedFor(t *testing.T) {
+	api := setupAPI(t)
+	api.Limiter.Routes["POST /auth/login"] = services.RateLimitRule{Limit: 1, Window: time.Minute}
+	login := func(forwardedFor string) int {
+		w := PerformAuthRequestWithHeaders(api.Router, "", "POST", "/auth/login", []byte(`{"username": "viewer", "password": "wrong"}`), map[string]string{"X-Forwarded-For": forwardedFor})
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, login("203.0.113.1"))
+	assert.Equal(t, http.StatusTooManyRequests, login("203.0.113.2"))
+	assert.Equal(t, http.StatusTooManyRequests, login("203.0.113.3"))
+
+	// behind a trusted proxy every forwarded client gets its own limit
+	require.NoError(t, server.SetTrustedProxies(api.Router, "192.0.2.0/24"))
+	assert.Equal(t, http.StatusUnauthorized, login("203.0.113.4"))
+	assert.Equal(t, http.StatusTooManyRequests, login("203.0.113.4"))
+	assert.Equal(t, http.StatusUnauthorized, login("203.0.113.5"))
+}
+
+func TestParseRateLimitRoutes(t *testing.T) {
+	routes, err := services.ParseRateLimitRoutes("GET  /info=60/1m, POST /music=5/10s,GET /search=0")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]services.RateLimitRule{
+		"GET /info":   {Limit: 60, Window: time.Minute},
+		"POST /music": {Limit: 5, Window: 10 * time.Second},
+		"GET /search": {},
+	}, routes)
+
+	for _, invalid := range []string{"GET /info", "GET /info=ten/1m", "GET /info=10/forever", "GET /info=10"} {
+		_, err := services.ParseRateLimitRoutes(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
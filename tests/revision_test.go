@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSongRevisions(t *testing.T) {
+	api := setupAPI(t)
+
+	w := api.RequestAs("editor", "POST", "/music", []byte(`{"group": "Muse", "song": "Hysteria"}`))
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created struct {
+		Song models.Music `json:"song"`
+	}
+	decodeJSON(t, w.Body.Bytes(), &created)
+	path := fmt.Sprintf("/music/%d", created.Song.ID)
+
+	body := []byte(`{"group": "Muse", "title": "Hysteria", "releaseDate": "2003-12-01T00:00:00Z", "text": "Edited lyrics", "link": "https://example.com/hysteria"}`)
+	w = api.RequestAs("editor", "PUT", path, body)
+	require.Equal(t, http.StatusOK, w.Code)
+	// saving the same values again is not a change
+	w = api.RequestAs("editor", "PUT", path, body)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var page types.PaginatedRevisionsResponse
+	w = api.RequestAs("viewer", "GET", path+"/revisions", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &page)
+	require.Equal(t, 2, page.TotalRevisions)
+	assert.Equal(t, 2, page.Data[0].Revision)
+	assert.Equal(t, models.RevisionUpdate, page.Data[0].Action)
+	assert.Equal(t, "editor", page.Data[0].Author)
+	assert.Equal(t, models.FieldChanges{{
+		Field:  "text",
+		Before: "It's bugging me\n\nGrating me\n\nAnd twisting me around",
+		After:  "Edited lyrics",
+	}}, page.Data[0].Changes)
+	assert.Equal(t, models.RevisionCreate, page.Data[1].Action)
+	assert.Equal(t, "Edited lyrics", page.Data[0].Snapshot.Text)
+
+	var diff types.RevisionDiffResponse
+	w = api.RequestAs("viewer", "GET", path+"/revisions/diff?from=1&to=2", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &diff)
+	assert.Equal(t, page.Data[0].Changes, diff.Changes)
+
+	w = api.RequestAs("viewer", "POST", path+"/revisions/1/restore", nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = api.RequestAs("editor", "POST", path+"/revisions/1/restore", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	restored, err := api.Songs.GetSongByID(created.Song.ID)
+	require.NoError(t, err)
+	assert.Equal(t, created.Song.Text, restored.Text)
+	assert.Equal(t, "primary", restored.Sources.Text)
+
+	w = api.Request("GET", path+"/revisions?limit=1", nil)
+	decodeJSON(t, w.Body.Bytes(), &page)
+	assert.Equal(t, 3, page.TotalRevisions)
+	assert.Equal(t, models.RevisionRollback, page.Data[0].Action)
+
+	w = api.Request("GET", "/info?group=Muse&song=Hysteria", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "It's bugging me")
+}
+
+func TestRevisionsOfSongStoredWithoutHistory(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Queen", Title: "Bohemian Rhapsody", Text: "Is this the real life?"})
+	path := fmt.Sprintf("/music/%d", songs[0].ID)
+
+	w := api.Request("PUT", path, []byte(`{"group": "Queen", "title": "Bohemian Rhapsody", "text": "Is this just fantasy?"}`))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var page types.PaginatedRevisionsResponse
+	w = api.Request("GET", path+"/revisions", nil)
+	decodeJSON(t, w.Body.Bytes(), &page)
+	require.Equal(t, 2, page.TotalRevisions)
+	assert.Equal(t, "admin", page.Data[0].Author)
+	assert.Equal(t, "Is this the real life?", page.Data[1].Snapshot.Text)
+
+	w = api.Request("POST", path+"/revisions/1/restore", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	restored, err := api.Songs.GetSongByID(songs[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Is this the real life?", restored.Text)
+}
+
+func TestRevisionErrors(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Queen", Title: "Bohemian Rhapsody"})
+	path := fmt.Sprintf("/music/%d", songs[0].ID)
+
+	for _, tc := range []struct {
+		method, path string
+		status       int
+	}{
+		{"GET", "/music/abc/revisions", http.StatusBadRequest},
+		{"GET", "/music/999/revisions", http.StatusNotFound},
+		{"GET", path + "/revisions/diff?from=1", http.StatusBadRequest},
+		{"GET", path + "/revisions/diff?from=1&to=2", http.StatusNotFound},
+		{"POST", path + "/revisions/0/restore", http.StatusBadRequest},
+		{"POST", path + "/revisions/5/restore", http.StatusNotFound},
+		{"POST", "/music/999/revisions/1/restore", http.StatusNotFound},
+	} {
+		w := api.Request(tc.method, tc.path, nil)
+		assert.Equal(t, tc.status, w.Code, tc.method+" "+tc.path)
+	}
+}
@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchSongs(t *testing.T) {
+	api := setupAPI(t)
+	seedSongs(t, api.Songs,
+		models.Music{Group: "Muse", Title: "Hysteria", Text: "It's bugging me\n\nGrating me"},
+		models.Music{Group: "Muse", Title: "Supermassive Black Hole", Text: "Ooh baby, don't you know I suffer?"},
+		models.Music{Group: "Radiohead", Title: "Creep", Text: "But I'm a creep\n\nI'm a weirdo"},
+		models.Music{Group: "Coldplay", Title: "Yellow", Text: "Look at the stars"},
+	)
+
+	w := api.Request("GET", "/search?q=mus", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response types.PaginatedSearchResponse
+	decodeJSON(t, w.Body.Bytes(), &response)
+	assert.Equal(t, 2, response.TotalResults)
+
+	w = api.Request("GET", "/search?q=creep", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &response)
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, "Creep", response.Data[0].Song.Title)
+	assert.Equal(t, "But I&#39;m a <mark>creep</mark>", response.Data[0].Snippet)
+
+	w = api.Request("GET", "/search?q=weird+I'm", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &response)
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, "<mark>I</mark>&#39;<mark>m</mark> a <mark>weirdo</mark>", response.Data[0].Snippet)
+}
+
+func TestSearchSnippetIsEscaped(t *testing.T) {
+	api := setupAPI(t)
+	seedSongs(t, api.Songs,
+		models.Music{Group: "Muse", Title: "Hysteria", Text: `<script>alert(1)</script> bugging <img src=x onerror="alert(2)">`},
+	)
+
+	w := api.Request("GET", "/search?q=bugging", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response types.PaginatedSearchResponse
+	decodeJSON(t, w.Body.Bytes(), &response)
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, "&lt;script&gt;alert(1)&lt;/script&gt; <mark>bugging</mark> &lt;img src=x onerror=&#34;alert(2)&#34;&gt;", response.Data[0].Snippet)
+}
+
+func TestSearchSongsRanking(t *testing.T) {
+	api := setupAPI(t)
+	seedSongs(t, api.Songs,
+		models.Music{Group: "Queen", Title: "Bohemian Rhapsody", Text: "Is this the real life?\n\nCaught in a landslide, no escape from reality"},
+		models.Music{Group: "Coldplay", Title: "Yellow", Text: "Yellow is the colour of the stars"},
+		models.Music{Group: "Muse", Title: "Stars Are Real", Text: "Nothing here"},
+	)
+
+	w := api.Request("GET", "/search?q=real", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response types.PaginatedSearchResponse
+	decodeJSON(t, w.Body.Bytes(), &response)
+	require.Len(t, response.Data, 2)
+	assert.Equal(t, "Stars Are Real", response.Data[0].Song.Title)
+	assert.Empty(t, response.Data[0].Snippet)
+	assert.Equal(t, "Is this the <mark>real</mark> life?", response.Data[1].Snippet)
+}
+
+func TestSearchSongsValidation(t *testing.T) {
+	api := setupAPI(t)
+
+	w := api.Request("GET", "/search", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = api.Request("GET", "/search?q=%21%21", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
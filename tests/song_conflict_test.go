@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddSongConflict(t *testing.T) {
+	api := setupAPI(t)
+
+	w := api.Request("POST", "/music", []byte(`{"group": "Muse", "song": "Hysteria"}`))
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created struct {
+		Song models.Music `json:"song"`
+	}
+	decodeJSON(t, w.Body.Bytes(), &created)
+
+	w = api.Request("POST", "/music", []byte(`{"group": " MUSE ", "song": "hysteria!"}`))
+	require.Equal(t, http.StatusConflict, w.Code)
+	var conflict types.SongConflictResponse
+	decodeJSON(t, w.Body.Bytes(), &conflict)
+	assert.Equal(t, created.Song.ID, conflict.SongID)
+
+	count, err := api.Songs.CountSongs(models.SongFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestUpdateAndRestoreSongConflicts(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs,
+		models.Music{Group: "Muse", Title: "Hysteria"},
+		models.Music{Group: "Muse", Title: "Uprising"},
+	)
+
+	w := api.Request("PUT", fmt.Sprintf("/music/%d", songs[1].ID), []byte(`{"group": "muse", "title": "HYSTERIA"}`))
+	require.Equal(t, http.StatusConflict, w.Code)
+	var conflict types.SongConflictResponse
+	decodeJSON(t, w.Body.Bytes(), &conflict)
+	assert.Equal(t, songs[0].ID, conflict.SongID)
+
+	// changing only the spelling of a song's own name is not a conflict
+	w = api.Request("PUT", fmt.Sprintf("/music/%d", songs[0].ID), []byte(`{"group": "MUSE", "title": "Hysteria"}`))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// a trashed song can be re-added, but then no longer restored
+	w = api.Request("DELETE", fmt.Sprintf("/music/%d", songs[1].ID), nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Uprising"})
+	w = api.Request("POST", fmt.Sprintf("/music/%d/restore", songs[1].ID), nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestUpsertSong(t *testing.T) {
+	api := setupAPI(t)
+
+	w := api.Request("PUT", "/music?group=Muse&song=Hysteria", []byte(`{"releaseDate": "2003-12-01", "text": "It's bugging me"}`))
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created struct {
+		Song models.Music `json:"song"`
+	}
+	decodeJSON(t, w.Body.Bytes(), &created)
+	assert.Equal(t, "Muse", created.Song.Group)
+	assert.Equal(t, "2003-12-01", created.Song.ReleaseDate.Format("2006-01-02"))
+	assert.Equal(t, models.SourceManual, created.Song.Sources.Text)
+	assert.Empty(t, created.Song.Sources.Link)
+
+	w = api.Request("PUT", "/music?group=muse&song=HYSTERIA", []byte(`{"releaseDate": "2003-12-01", "text": "Grating me", "link": "https://example.com/hysteria"}`))
+	require.Equal(t, http.StatusOK, w.Code)
+	var updated struct {
+		Song models.Music `json:"song"`
+	}
+	decodeJSON(t, w.Body.Bytes(), &updated)
+	assert.Equal(t, created.Song.ID, updated.Song.ID)
+	assert.Equal(t, "Hysteria", updated.Song.Title)
+	assert.Equal(t, "Grating me", updated.Song.Text)
+	assert.Equal(t, models.SourceManual, updated.Song.Sources.Link)
+
+	revisions, err := api.Revisions.CountRevisions(created.Song.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, revisions)
+
+	for _, tc := range []struct {
+		path string
+		body string
+	}{
+		{"/music?group=Muse", `{}`},
+		{"/music?group=Muse&song=Hysteria", `{"releaseDate": "01.12.2003"}`},
+		{"/music?group=Muse&song=Hysteria", `{"link": "not a url"}`},
+		{"/music?group=Muse&song=Hysteria", `not json`},
+	} {
+		w = api.Request("PUT", tc.path, []byte(tc.body))
+		assert.Equal(t, http.StatusBadRequest, w.Code, tc)
+	}
+
+	w = api.RequestAs("viewer", "PUT", "/music?group=Muse&song=Hysteria", []byte(`{}`))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
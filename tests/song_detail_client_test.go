@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/services"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnrichSong(t *testing.T) {
+	server := NewFakeSongDetailServer(map[string]types.SongDetail{
+		"Muse:Supermassive Black Hole": {
+			ReleaseDate: "16.07.2006",
+			Text:        "Ooh baby, don't you know I suffer?\n\nOoh baby, can you hear me moan?",
+			Link:        "https://www.youtube.com/watch?v=Xsp3_a-PMTw",
+		},
+	})
+	defer server.Close()
+
+	service := services.NewMusicService(nil, nil, nil, nil, services.NewEnrichmentChain(services.NewSongDetailClient("primary", server.URL, time.Second)), time.Hour)
+
+	song := &models.Music{Group: "Muse", Title: "Supermassive Black Hole"}
+	err := service.EnrichSong(song)
+	assert.NoError(t, err)
+	assert.Equal(t, "2006-07-16", song.ReleaseDate.Format("2006-01-02"))
+	assert.Equal(t, "https://www.youtube.com/watch?v=Xsp3_a-PMTw", song.Link)
+	assert.Contains(t, song.Text, "Ooh baby")
+	assert.Equal(t, models.FieldSources{ReleaseDate: "primary", Text: "primary", Link: "primary"}, song.Sources)
+
+	err = service.EnrichSong(&models.Music{Group: "Muse", Title: "Unknown"})
+	assert.ErrorIs(t, err, services.ErrSongDetailNotFound)
+}
+
+func TestEnrichSongUpstreamDown(t *testing.T) {
+	server := NewFakeSongDetailServer(nil)
+	server.Close()
+
+	service := services.NewMusicService(nil, nil, nil, nil, services.NewEnrichmentChain(services.NewSongDetailClient("primary", server.URL, time.Second)), time.Hour)
+	err := service.EnrichSong(&models.Music{Group: "Muse", Title: "Hysteria"})
+	assert.ErrorIs(t, err, services.ErrSongDetailUnavailable)
+}
+
+func TestEnrichSongUpstreamErrors(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	service := services.NewMusicService(nil, nil, nil, nil, services.NewEnrichmentChain(services.NewSongDetailClient("primary", failing.URL, time.Second)), time.Hour)
+	err := service.EnrichSong(&models.Music{Group: "Muse", Title: "Hysteria"})
+	assert.ErrorIs(t, err, services.ErrSongDetailUnavailable)
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer slow.Close()
+
+	service = services.NewMusicService(nil, nil, nil, nil, services.NewEnrichmentChain(services.NewSongDetailClient("primary", slow.URL, 50*time.Millisecond)), time.Hour)
+	err = service.EnrichSong(&models.Music{Group: "Muse", Title: "Hysteria"})
+	assert.ErrorIs(t, err, services.ErrSongDetailTimeout)
+}
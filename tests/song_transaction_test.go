@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/repositories"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingRevisionStore fails every AddRevision while Fail is set.
+type failingRevisionStore struct {
+	*repositories.InMemoryRevisionRepository
+	Fail bool
+}
+
+func (s *failingRevisionStore) AddRevision(revision *models.SongRevision) error {
+	if s.Fail {
+		return errors.New("revision store is down")
+	}
+	return s.InMemoryRevisionRepository.AddRevision(revision)
+}
+
+// setupFailingRevisions makes the music service of api record revisions
+// through a store that can be made to fail.
+func setupFailingRevisions(api *testAPI) *failingRevisionStore {
+	revisions := &failingRevisionStore{InMemoryRevisionRepository: api.Revisions}
+	api.Music.RevisionRepo = revisions
+	api.Music.Transactor = repositories.NewInMemorySongTransaction(api.Songs, revisions)
+	return revisions
+}
+
+func TestSongWriteRollsBackWhenRevisionFails(t *testing.T) {
+	api := setupAPI(t)
+	revisions := setupFailingRevisions(api)
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria", Text: "old lyrics"})
+	path := fmt.Sprintf("/music/%d", songs[0].ID)
+
+	w := api.Request("GET", "/info?group=Muse&song=Hysteria", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	revisions.Fail = true
+	w = api.Request("PUT", path, []byte(`{"group": "Muse", "title": "Hysteria", "text": "new lyrics"}`))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	w = api.Request("DELETE", path, nil)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	w = api.RequestAs("editor", "POST", "/music", []byte(`{"group": "The Beatles", "song": "Hey Jude"}`))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	song, err := api.Songs.GetSongByID(songs[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "old lyrics", song.Text)
+	assert.Equal(t, uint(1), song.Version)
+	count, err := api.Songs.CountSongs(models.SongFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	revisions.Fail = false
+	w = api.Request("PUT", path, []byte(`{"group": "Muse", "title": "Hysteria", "text": "new lyrics"}`))
+	require.Equal(t, http.StatusOK, w.Code)
+	w = api.Request("GET", "/info?group=Muse&song=Hysteria", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "new lyrics")
+}
+
+func TestFailedWriteStillEvictsCache(t *testing.T) {
+	api := setupAPI(t)
+	revisions := setupFailingRevisions(api)
+	api.Music.Transactor = nil
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria", Text: "old lyrics"})
+
+	w := api.Request("GET", "/info?group=Muse&song=Hysteria", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// without a transaction the song is saved although its revision is not
+	revisions.Fail = true
+	w = api.Request("PUT", fmt.Sprintf("/music/%d", songs[0].ID), []byte(`{"group": "Muse", "title": "Hysteria", "text": "new lyrics"}`))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	w = api.Request("GET", "/info?group=Muse&song=Hysteria", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "new lyrics")
+}
+
+func TestImportBatchRollsBackWhenRevisionFails(t *testing.T) {
+	api := setupAPI(t)
+	revisions := setupFailingRevisions(api)
+	revisions.Fail = true
+
+	csv := "group,title,releaseDate,text,link\n" +
+		"Coldplay,Yellow,2000-06-26,Look at the stars,\n" +
+		"Coldplay,Fix You,,Lights will guide you home,\n"
+	w := api.RequestAs("editor", "POST", "/music/import?format=csv", []byte(csv))
+	require.Equal(t, http.StatusOK, w.Code)
+	var result types.ImportResult
+	decodeJSON(t, w.Body.Bytes(), &result)
+	assert.Equal(t, 2, result.Failed)
+	assert.Equal(t, "failed to save the batch", result.Rows[0].Error)
+
+	count, err := api.Songs.CountSongs(models.SongFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
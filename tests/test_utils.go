@@ -19,3 +19,37 @@ func PerformRequest(router *gin.Engine, method, path string, body []byte) *httpt
 	router.ServeHTTP(w, req)
 	return w
 }
+
+// PerformAuthRequest is PerformRequest with an "Authorization: Bearer" header.
+func PerformAuthRequest(router *gin.Engine, token, method, path string, body []byte) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// PerformAuthRequestWithHeaders is PerformAuthRequest with extra headers,
+// which may override Content-Type.
+func PerformAuthRequestWithHeaders(router *gin.Engine, token, method, path string, body []byte, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// PerformAPIKeyRequest is PerformRequest with an "X-API-Key" header.
+func PerformAPIKeyRequest(router *gin.Engine, key, method, path string, body []byte) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", key)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/srmbackisdeveloper/test-music-info/internal/models"
+	"github.com/srmbackisdeveloper/test-music-info/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrashAndRestore(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs,
+		models.Music{Group: "Muse", Title: "Hysteria", Text: "It's bugging me"},
+		models.Music{Group: "Queen", Title: "Bohemian Rhapsody"},
+	)
+	path := fmt.Sprintf("/music/%d", songs[0].ID)
+
+	w := api.Request("GET", "/info?group=Muse&song=Hysteria", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = api.Request("DELETE", path, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = api.Request("GET", "/info?group=Muse&song=Hysteria", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = api.Request("GET", "/search?q=hysteria", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"totalResults":0`)
+
+	var page types.PaginatedSongsResponse
+	w = api.Request("GET", "/music", nil)
+	decodeJSON(t, w.Body.Bytes(), &page)
+	assert.Equal(t, 1, page.TotalSongs)
+
+	w = api.RequestAs("editor", "GET", "/music/trash", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &page)
+	require.Equal(t, 1, page.TotalSongs)
+	assert.Equal(t, songs[0].ID, page.Data[0].ID)
+	assert.True(t, page.Data[0].DeletedAt.Valid)
+
+	w = api.RequestAs("editor", "POST", path+"/restore", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = api.Request("GET", "/info?group=Muse&song=Hysteria", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = api.Request("GET", "/music/trash", nil)
+	decodeJSON(t, w.Body.Bytes(), &page)
+	assert.Equal(t, 0, page.TotalSongs)
+
+	w = api.Request("POST", path+"/restore", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = api.RequestAs("viewer", "GET", "/music/trash", nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestPurgeTrash(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs,
+		models.Music{Group: "Muse", Title: "Hysteria"},
+		models.Music{Group: "Queen", Title: "Bohemian Rhapsody"},
+	)
+	w := api.Request("DELETE", fmt.Sprintf("/music/%d", songs[0].ID), nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = api.RequestAs("editor", "DELETE", "/music/trash", nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// nothing is older than the default retention yet
+	var purge types.PurgeTrashResponse
+	w = api.Request("DELETE", "/music/trash", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &purge)
+	assert.Equal(t, 0, purge.Purged)
+
+	api.Music.TrashRetention = 0
+	w = api.Request("DELETE", "/music/trash", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	decodeJSON(t, w.Body.Bytes(), &purge)
+	assert.Equal(t, 1, purge.Purged)
+
+	w = api.Request("POST", fmt.Sprintf("/music/%d/restore", songs[0].ID), nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = api.Request("GET", "/music", nil)
+	assert.Contains(t, w.Body.String(), `"totalSongs":1`)
+}
+
+func TestTrashAndRestoreAreVersionedRevisions(t *testing.T) {
+	api := setupAPI(t)
+	songs := seedSongs(t, api.Songs, models.Music{Group: "Muse", Title: "Hysteria"})
+	path := fmt.Sprintf("/music/%d", songs[0].ID)
+
+	w := api.Request("DELETE", path, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	w = api.RequestAs("editor", "POST", path+"/restore", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, songETag(songs[0].ID, 3), w.Header().Get("ETag"))
+
+	// the ETag read before the delete no longer matches
+	w = api.RequestWithHeaders("PATCH", path, []byte(`{"text": "stale"}`), map[string]string{"If-Match": songETag(songs[0].ID, 1)})
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+
+	revisions, err := api.Revisions.ListRevisions(songs[0].ID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, revisions, 3)
+	assert.Equal(t, models.RevisionRestore, revisions[0].Action)
+	assert.Equal(t, "editor", revisions[0].Author)
+	assert.Equal(t, models.RevisionDelete, revisions[1].Action)
+	assert.Equal(t, "admin", revisions[1].Author)
+	assert.Equal(t, models.RevisionCreate, revisions[2].Action)
+}